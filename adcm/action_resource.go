@@ -0,0 +1,247 @@
+package adcm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &actionResource{}
+	_ resource.ResourceWithConfigure      = &actionResource{}
+	_ resource.ResourceWithValidateConfig = &actionResource{}
+)
+
+// NewActionResource is a helper function to simplify the provider implementation.
+func NewActionResource() resource.Resource {
+	return &actionResource{}
+}
+
+// actionResource is the resource implementation.
+type actionResource struct {
+	client *adcmClient.Client
+}
+
+// actionResourceModel maps action run data.
+type actionResourceModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	ResourceID types.Int64  `tfsdk:"resource_id"`
+	Type       types.String `tfsdk:"type"`
+	Action     types.String `tfsdk:"action"`
+	Config     types.String `tfsdk:"config"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	TaskID     types.Int64  `tfsdk:"task_id"`
+	Status     types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (r *actionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action"
+}
+
+// Schema defines the schema for the resource.
+func (r *actionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs an action on ADCM object. Any change of arguments runs the action again, destroy does nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the action run, equal to task_id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_id": schema.Int64Attribute{
+				Description: "ID of object to run action on, required for all types except adcm. Ignored for adcm type as ADCM has the only such object.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of object to run action on, one of " + strings.Join(adcmClient.ActionObjectTypes, ", ") + ".",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Description: "Name of action to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.StringAttribute{
+				Description: "Config of action in JSON string, deep-merged over the default values of action config. " +
+					"Fields are validated against config schema of the action before the run.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values which runs the action again when changed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_id": schema.Int64Attribute{
+				Description: "ID of ADCM task created by the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Final status of ADCM task created by the action.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks type of object and presence of its ID.
+func (r *actionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config actionResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if config.Type.IsUnknown() {
+		return
+	}
+	objectType := config.Type.ValueString()
+	valid := false
+	for _, t := range adcmClient.ActionObjectTypes {
+		if objectType == t {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid object type",
+			fmt.Sprintf("Type %q is not supported, expected one of %s.", objectType, strings.Join(adcmClient.ActionObjectTypes, ", ")),
+		)
+		return
+	}
+	if objectType != adcmClient.ActionObjectADCM && config.ResourceID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource_id"),
+			"Missing object ID",
+			fmt.Sprintf("resource_id must be set for %s type.", objectType),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *actionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*adcmClient.Client)
+}
+
+// Create runs the action and sets the initial Terraform state.
+func (r *actionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan actionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := expandActionConfig(plan.Config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running action",
+			err.Error(),
+		)
+		return
+	}
+
+	// ADCM object is looked up by the client
+	objectID := plan.ResourceID.ValueInt64()
+	if plan.Type.ValueString() == adcmClient.ActionObjectADCM {
+		objectID = 0
+	}
+
+	// Run action and wait for the task
+	tflog.Info(ctx, "Running ADCM action", map[string]any{"type": plan.Type.ValueString(), "resource_id": objectID, "action": plan.Action.ValueString()})
+	task, err := r.client.RunAction(ctx, plan.Type.ValueString(), objectID, plan.Action.ValueString(), config, nil, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running action",
+			fmt.Sprintf("Could not run action %q on %s %d: %s", plan.Action.ValueString(), plan.Type.ValueString(), objectID, err),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.Int64Value(task.ID)
+	plan.TaskID = types.Int64Value(task.ID)
+	plan.Status = types.StringValue(task.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the Terraform state as is, finished action run does not change.
+func (r *actionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state actionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is not expected to be called as any change of arguments requires replacement.
+func (r *actionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan actionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the Terraform state only, results of action are left in ADCM.
+func (r *actionResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,109 @@
+package adcm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestActionResource(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/adcm/":
+			_, _ = w.Write([]byte(`[{"id": 1}]`))
+		case "/api/v1/adcm/1/action/":
+			_, _ = w.Write([]byte(`[{"id": 7}]`))
+		case "/api/v1/adcm/1/action/7/":
+			_, _ = w.Write([]byte(`{"id": 7, "config": null}`))
+		case "/api/v1/adcm/1/action/7/run/":
+			_, _ = w.Write([]byte(`{"id": 42}`))
+		case "/api/v1/task/42/":
+			_, _ = w.Write([]byte(`{"id": 42, "status": "success"}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := adcmClient.NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &actionResource{client: client}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	emptyValue := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	// resource_id is ignored for adcm type
+	model := actionResourceModel{
+		ID:         types.Int64Unknown(),
+		ResourceID: types.Int64Value(100),
+		Type:       types.StringValue(adcmClient.ActionObjectADCM),
+		Action:     types.StringValue("Check"),
+		Config:     types.StringNull(),
+		Triggers:   types.MapNull(types.StringType),
+		TaskID:     types.Int64Unknown(),
+		Status:     types.StringUnknown(),
+	}
+	createReq := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: emptyValue}}
+	if diags := createReq.Plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: emptyValue}}
+	r.Create(ctx, createReq, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	var state actionResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID.ValueInt64() != 42 || state.TaskID.ValueInt64() != 42 || state.Status.ValueString() != "success" {
+		t.Errorf("Unexpected state of action run: %+v", state)
+	}
+
+	// destroy leaves results of action in ADCM
+	requests = nil
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &resource.DeleteResponse{State: createResp.State})
+	if len(requests) != 0 {
+		t.Errorf("Unexpected requests on delete: %v", requests)
+	}
+
+	// type and resource_id are validated before apply
+	for _, tc := range []struct {
+		objectType string
+		resourceID types.Int64
+		valid      bool
+	}{
+		{"cluster", types.Int64Value(1), true},
+		{"adcm", types.Int64Null(), true},
+		{"cluster", types.Int64Null(), false},
+		{"bundle", types.Int64Value(1), false},
+	} {
+		model.Type = types.StringValue(tc.objectType)
+		model.ResourceID = tc.resourceID
+		config := tfsdk.Config{Schema: schemaResp.Schema}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: emptyValue}
+		if diags := plan.Set(ctx, &model); diags.HasError() {
+			t.Fatal(diags)
+		}
+		config.Raw = plan.Raw
+		validateResp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, validateResp)
+		if validateResp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("Unexpected validation of type %s with resource_id %s: %v", tc.objectType, tc.resourceID, validateResp.Diagnostics)
+		}
+	}
+}
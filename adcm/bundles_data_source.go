@@ -110,7 +110,7 @@ func (d *bundleDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		License:     requestOptions.License.ValueString(),
 		Version:     requestOptions.Version.ValueString(),
 	}
-	bundle, err := d.client.GetBundle(opts)
+	bundle, err := d.client.GetBundle(ctx, opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read ADCM Bundle",
@@ -3,6 +3,7 @@ package adcm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -42,6 +44,7 @@ type clusterResourceModel struct {
 	ServicesConfig types.String `tfsdk:"services_config"`
 	HCMap          types.String `tfsdk:"hc_map"`
 	Action         types.String `tfsdk:"action"`
+	ActionConfig   types.String `tfsdk:"action_config"`
 }
 
 // Metadata returns the data source type name.
@@ -72,6 +75,9 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"bundle_id": schema.Int64Attribute{
 				Description: "Bundle ID of cluster.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"cluster_config": schema.StringAttribute{
 				Description: "Config of cluster in JSON string to apply.",
@@ -89,6 +95,11 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "action to run",
 				Optional:    true,
 			},
+			"action_config": schema.StringAttribute{
+				Description: "Config of action in JSON string, deep-merged over the default values of action config.",
+				Optional:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
@@ -113,43 +124,17 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Generate API request body from plan
-	var cluster adcmClient.Cluster
-	cluster.BundleID = plan.BundleID.ValueInt64()
-	cluster.Name = plan.Name.ValueString()
-	cluster.Description = plan.Description.ValueString()
-	if plan.ClusterConfig.ValueString() != "" {
-		err := json.Unmarshal([]byte(plan.ClusterConfig.ValueString()), &cluster.ClusterConfig.Config)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating cluster",
-				"Could not unmarshal cluster config of cluster, unexpected error: "+err.Error(),
-			)
-			return
-		}
-	}
-	if plan.ServicesConfig.ValueString() != "" {
-		err := json.Unmarshal([]byte(plan.ServicesConfig.ValueString()), &cluster.ServicesConfig.Config)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating cluster",
-				"Could not unmarshal services config of cluster, unexpected error: "+err.Error(),
-			)
-			return
-		}
-	}
-	if plan.HCMap.ValueString() != "" {
-		err := json.Unmarshal([]byte(plan.HCMap.ValueString()), &cluster.HCMap)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating cluster",
-				"Could not unmarshal hc map of cluster, unexpected error: "+err.Error(),
-			)
-			return
-		}
+	cluster, err := expandCluster(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster",
+			err.Error(),
+		)
+		return
 	}
 
 	// Create new cluster
-	h, err := r.client.CreateCluster(cluster)
+	h, err := r.client.CreateCluster(ctx, cluster)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster",
@@ -159,7 +144,7 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if plan.Action.ValueString() != "" {
-		err := r.client.ClusterAction(adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: h.ID}}, plan.Action.ValueString(), true)
+		err = r.runAction(ctx, h.ID, plan)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating cluster",
@@ -192,7 +177,12 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get refreshed cluster value from ADCM
-	h, err := r.client.GetCluster(adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	h, err := r.client.GetCluster(ctx, adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if errors.Is(err, adcmClient.ErrNotFound) {
+		tflog.Warn(ctx, "ADCM cluster not found, removing from state", map[string]any{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading ADCM cluster",
@@ -208,6 +198,61 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		state.Description = types.StringValue(h.Description)
 	}
 	state.BundleID = types.Int64Value(h.BundleID)
+	if state.ClusterConfig.ValueString() != "" {
+		clusterConfig, err := r.client.GetClusterConfig(ctx, h.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not read config of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+		state.ClusterConfig, err = refreshJSONConfig(state.ClusterConfig, clusterConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not refresh config of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+	}
+	if state.ServicesConfig.ValueString() != "" {
+		servicesConfig, err := r.client.GetServicesConfig(ctx, adcmClient.ClusterSearch{Identifier: h.Identifier})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not read services config of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+		state.ServicesConfig, err = refreshJSONConfig(state.ServicesConfig, servicesConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not refresh services config of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+	}
+
+	if state.HCMap.ValueString() != "" {
+		hcMap, err := r.client.GetHCMap(ctx, adcmClient.ClusterSearch{Identifier: h.Identifier})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not read hc map of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+		state.HCMap, err = refreshHCMap(state.HCMap, hcMap)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM cluster",
+				fmt.Sprintf("Could not refresh hc map of ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -219,10 +264,73 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error Update ADCM cluster",
-		fmt.Sprintf("%+v", req),
-	)
+	// Retrieve values from plan
+	var plan clusterResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state clusterResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	cluster, err := expandCluster(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster",
+			err.Error(),
+		)
+		return
+	}
+	cluster.ID = state.ID.ValueInt64()
+	// secrets are encrypted by ADCM, so their changes are detected against state
+	if prior, err := expandCluster(state); err == nil {
+		cluster.PriorClusterConfig = prior.ClusterConfig.Config
+		cluster.PriorServicesConfig = prior.ServicesConfig.Config
+	}
+	// hc_map removed from configuration clears mapping managed before
+	if cluster.HCMap == nil && state.HCMap.ValueString() != "" {
+		cluster.HCMap = make(map[string][]map[string][]string)
+	}
+
+	// Update existing cluster
+	h, err := r.client.UpdateCluster(ctx, cluster)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster",
+			fmt.Sprintf("Could not update ADCM cluster ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	if plan.Action.ValueString() != "" && (!plan.Action.Equal(state.Action) || !plan.ActionConfig.Equal(state.ActionConfig)) {
+		err = r.runAction(ctx, h.ID, plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating cluster",
+				"Could not run action on cluster, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.Int64Value(h.ID)
+	plan.BundleID = types.Int64Value(h.BundleID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -236,8 +344,8 @@ func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	// Delete existing cluster
-	err := r.client.DeleteCluster(adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
-	if err != nil {
+	err := r.client.DeleteCluster(ctx, adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if err != nil && !errors.Is(err, adcmClient.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting ADCM cluster",
 			"Could not delete cluster, unexpected error: "+err.Error(),
@@ -250,3 +358,39 @@ func (r *clusterResource) ImportState(ctx context.Context, req resource.ImportSt
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// runAction runs action of the plan on cluster and waits for it to finish.
+func (r *clusterResource) runAction(ctx context.Context, clusterID int64, plan clusterResourceModel) error {
+	config, err := expandActionConfig(plan.ActionConfig)
+	if err != nil {
+		return err
+	}
+	return r.client.ClusterAction(ctx, adcmClient.ClusterSearch{Identifier: adcmClient.Identifier{ID: clusterID}}, plan.Action.ValueString(), config, true)
+}
+
+// expandCluster builds API request body from resource model.
+func expandCluster(model clusterResourceModel) (adcmClient.Cluster, error) {
+	var cluster adcmClient.Cluster
+	cluster.BundleID = model.BundleID.ValueInt64()
+	cluster.Name = model.Name.ValueString()
+	cluster.Description = model.Description.ValueString()
+	if model.ClusterConfig.ValueString() != "" {
+		err := json.Unmarshal([]byte(model.ClusterConfig.ValueString()), &cluster.ClusterConfig.Config)
+		if err != nil {
+			return cluster, fmt.Errorf("could not unmarshal cluster config of cluster, unexpected error: %s", err)
+		}
+	}
+	if model.ServicesConfig.ValueString() != "" {
+		err := json.Unmarshal([]byte(model.ServicesConfig.ValueString()), &cluster.ServicesConfig.Config)
+		if err != nil {
+			return cluster, fmt.Errorf("could not unmarshal services config of cluster, unexpected error: %s", err)
+		}
+	}
+	if model.HCMap.ValueString() != "" {
+		err := json.Unmarshal([]byte(model.HCMap.ValueString()), &cluster.HCMap)
+		if err != nil {
+			return cluster, fmt.Errorf("could not unmarshal hc map of cluster, unexpected error: %s", err)
+		}
+	}
+	return cluster, nil
+}
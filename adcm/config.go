@@ -0,0 +1,115 @@
+package adcm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// refreshJSONConfig refreshes JSON config attribute with actual config from ADCM.
+// Only keys defined in prior value are taken into account, so defaults of ADCM
+// objects do not produce diff. Prior value is kept as is if it is equal to the
+// actual one after normalization to preserve formatting of the user.
+func refreshJSONConfig(prior types.String, actual map[string]interface{}) (types.String, error) {
+	if prior.IsNull() || prior.IsUnknown() || prior.ValueString() == "" {
+		return prior, nil
+	}
+	var managed map[string]interface{}
+	err := json.Unmarshal([]byte(prior.ValueString()), &managed)
+	if err != nil {
+		return prior, err
+	}
+	projected, err := normalizeConfig(projectConfig(actual, managed))
+	if err != nil {
+		return prior, err
+	}
+	normalized, err := normalizeConfig(managed)
+	if err != nil {
+		return prior, err
+	}
+	if reflect.DeepEqual(projected, normalized) {
+		return prior, nil
+	}
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return prior, err
+	}
+	return types.StringValue(string(data)), nil
+}
+
+// refreshHCMap refreshes JSON host-component mapping attribute with actual mapping from ADCM.
+// Prior value is kept as is if it places the same components on the same hosts.
+func refreshHCMap(prior types.String, actual map[string][]map[string][]string) (types.String, error) {
+	if prior.IsNull() || prior.IsUnknown() || prior.ValueString() == "" {
+		return prior, nil
+	}
+	var managed map[string][]map[string][]string
+	err := json.Unmarshal([]byte(prior.ValueString()), &managed)
+	if err != nil {
+		return prior, err
+	}
+	if adcmClient.HCMapEqual(managed, actual) {
+		return prior, nil
+	}
+	data, err := json.Marshal(actual)
+	if err != nil {
+		return prior, err
+	}
+	return types.StringValue(string(data)), nil
+}
+
+// projectConfig returns part of actual config limited to keys of managed config.
+// Secrets are returned by ADCM encrypted, so managed values are kept for them.
+func projectConfig(actual, managed map[string]interface{}) map[string]interface{} {
+	projected := make(map[string]interface{})
+	for key, managedValue := range managed {
+		actualValue, defined := actual[key]
+		if !defined {
+			continue
+		}
+		managedGroup, managedIsGroup := managedValue.(map[string]interface{})
+		actualGroup, actualIsGroup := actualValue.(map[string]interface{})
+		if managedIsGroup && actualIsGroup {
+			projected[key] = projectConfig(actualGroup, managedGroup)
+			continue
+		}
+		if adcmClient.IsVaultEncrypted(actualValue) && !adcmClient.IsVaultEncrypted(managedValue) {
+			projected[key] = managedValue
+			continue
+		}
+		projected[key] = actualValue
+	}
+	return projected
+}
+
+// normalizeConfig passes config through JSON encoding to get rid of
+// differences in numeric types and nested map types.
+func normalizeConfig(config map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	err = json.Unmarshal(data, &normalized)
+	if err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// expandActionConfig parses action config attribute, empty value means defaults of the action.
+func expandActionConfig(value types.String) (map[string]interface{}, error) {
+	if value.ValueString() == "" {
+		return nil, nil
+	}
+	var config map[string]interface{}
+	err := json.Unmarshal([]byte(value.ValueString()), &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal action config, unexpected error: %s", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,79 @@
+package adcm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshJSONConfig(t *testing.T) {
+	actual := map[string]interface{}{
+		"ansible_user": "adcm",
+		"ansible_port": 22,
+		"repos":        map[string]interface{}{"use_repo": true, "url": "http://repo"},
+		"unmanaged":    "value",
+	}
+	prior := types.StringValue(`{"repos": {"use_repo": true}, "ansible_port": 22.0, "ansible_user": "adcm"}`)
+	refreshed, err := refreshJSONConfig(prior, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.Equal(prior) {
+		t.Errorf("Unexpected drift detected: %s", refreshed.ValueString())
+	}
+
+	actual["ansible_user"] = "root"
+	refreshed, err = refreshJSONConfig(prior, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"ansible_port":22,"ansible_user":"root","repos":{"use_repo":true}}`
+	if refreshed.ValueString() != expected {
+		t.Errorf("Unexpected refreshed config: %s", refreshed.ValueString())
+	}
+
+	// encrypted secrets do not produce drift
+	actual["ansible_ssh_pass"] = "$ANSIBLE_VAULT;1.1;AES256\n6135"
+	actual["repos"].(map[string]interface{})["password"] = "$ANSIBLE_VAULT;1.1;AES256\n3961"
+	prior = types.StringValue(`{"ansible_user": "root", "ansible_ssh_pass": "secret", "repos": {"password": "repo secret"}}`)
+	refreshed, err = refreshJSONConfig(prior, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.Equal(prior) {
+		t.Errorf("Drift of encrypted secrets detected: %s", refreshed.ValueString())
+	}
+
+	refreshed, err = refreshJSONConfig(types.StringNull(), actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.IsNull() {
+		t.Error("Unmanaged config refreshed")
+	}
+}
+
+func TestRefreshHCMap(t *testing.T) {
+	actual := map[string][]map[string][]string{
+		"h1": {{"adpg": {"adpg"}, "monitoring": {"node_exporter"}}},
+	}
+	prior := types.StringValue(`{"h1": [{"monitoring": ["node_exporter"]}, {"adpg": ["adpg"]}]}`)
+	refreshed, err := refreshHCMap(prior, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.Equal(prior) {
+		t.Errorf("Unexpected drift detected: %s", refreshed.ValueString())
+	}
+
+	actual["h2"] = actual["h1"]
+	delete(actual, "h1")
+	refreshed, err = refreshHCMap(prior, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"h2":[{"adpg":["adpg"],"monitoring":["node_exporter"]}]}`
+	if refreshed.ValueString() != expected {
+		t.Errorf("Unexpected refreshed hc map: %s", refreshed.ValueString())
+	}
+}
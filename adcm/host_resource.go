@@ -3,6 +3,7 @@ package adcm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
@@ -12,7 +13,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -61,10 +64,16 @@ func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"fqdn": schema.StringAttribute{
 				Description: "FQDN of host.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"provider_id": schema.Int64Attribute{
 				Description: "Provider ID of host.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description: "FQDN of host.",
@@ -98,23 +107,17 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Generate API request body from plan
-	var host adcmClient.Host
-	host.ProviderID = plan.ProviderID.ValueInt64()
-	host.FQDN = plan.FQDN.ValueString()
-	host.Description = plan.Description.ValueString()
-	if plan.Config.ValueString() != "" {
-		err := json.Unmarshal([]byte(plan.Config.ValueString()), &host.Config)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating host",
-				"Could not create host, unexpected error: "+err.Error(),
-			)
-			return
-		}
+	host, err := expandHost(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating host",
+			"Could not create host, unexpected error: "+err.Error(),
+		)
+		return
 	}
 
 	// Create new host
-	h, err := r.client.CreateHost(host)
+	h, err := r.client.CreateHost(ctx, host)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating host",
@@ -146,7 +149,12 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed host value from ADCM
-	h, err := r.client.GetHost(adcmClient.HostSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	h, err := r.client.GetHost(ctx, adcmClient.HostSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if errors.Is(err, adcmClient.ErrNotFound) {
+		tflog.Warn(ctx, "ADCM host not found, removing from state", map[string]any{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading ADCM host",
@@ -164,6 +172,24 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if h.ProviderID != 0 {
 		state.ProviderID = types.Int64Value(h.ProviderID)
 	}
+	if state.Config.ValueString() != "" {
+		config, err := r.client.GetHostConfig(ctx, h.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM host",
+				fmt.Sprintf("Could not read config of ADCM host ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+		state.Config, err = refreshJSONConfig(state.Config, config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM host",
+				fmt.Sprintf("Could not refresh config of ADCM host ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -175,10 +201,57 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error Update ADCM host",
-		fmt.Sprintf("%+v", req),
-	)
+	// Retrieve values from plan
+	var plan hostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state hostResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	host, err := expandHost(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating host",
+			"Could not update host, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	host.ID = state.ID.ValueInt64()
+	// secrets are encrypted by ADCM, so their changes are detected against state
+	if prior, err := expandHost(state); err == nil {
+		host.PriorConfig = prior.Config
+	}
+
+	// Update existing host
+	h, err := r.client.UpdateHost(ctx, host)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating host",
+			fmt.Sprintf("Could not update ADCM host ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.Int64Value(h.ID)
+	plan.ProviderID = types.Int64Value(h.ProviderID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -192,8 +265,8 @@ func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Delete existing host
-	err := r.client.DeleteHost(adcmClient.HostSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
-	if err != nil {
+	err := r.client.DeleteHost(ctx, adcmClient.HostSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if err != nil && !errors.Is(err, adcmClient.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting ADCM host",
 			"Could not delete host, unexpected error: "+err.Error(),
@@ -206,3 +279,18 @@ func (r *hostResource) ImportState(ctx context.Context, req resource.ImportState
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// expandHost builds API request body from resource model.
+func expandHost(model hostResourceModel) (adcmClient.Host, error) {
+	var host adcmClient.Host
+	host.ProviderID = model.ProviderID.ValueInt64()
+	host.FQDN = model.FQDN.ValueString()
+	host.Description = model.Description.ValueString()
+	if model.Config.ValueString() != "" {
+		err := json.Unmarshal([]byte(model.Config.ValueString()), &host.Config)
+		if err != nil {
+			return host, err
+		}
+	}
+	return host, nil
+}
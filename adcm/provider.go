@@ -2,11 +2,16 @@ package adcm
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -32,6 +37,32 @@ type adcmProviderModel struct {
 	Url      types.String `tfsdk:"url"`
 	Login    types.String `tfsdk:"login"`
 	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+
+	RetryMaxAttempts types.Int64  `tfsdk:"retry_max_attempts"`
+	RetryWaitMin     types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax     types.String `tfsdk:"retry_wait_max"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	UploadTimeout  types.String `tfsdk:"upload_timeout"`
+	TaskTimeout    types.String `tfsdk:"task_timeout"`
+
+	TaskPollInterval  types.String `tfsdk:"task_poll_interval"`
+	CancelOnInterrupt types.Bool   `tfsdk:"cancel_on_interrupt"`
+	LogLevel          types.String `tfsdk:"log_level"`
+	TaskEvents        types.Bool   `tfsdk:"task_events"`
+
+	BundleMirror               types.String `tfsdk:"bundle_mirror"`
+	DownloadRetryMaxAttempts   types.Int64  `tfsdk:"download_retry_max_attempts"`
+	DownloadCACertFile         types.String `tfsdk:"download_ca_cert_file"`
+	DownloadCACertPEM          types.String `tfsdk:"download_ca_cert_pem"`
+	DownloadInsecureSkipVerify types.Bool   `tfsdk:"download_insecure_skip_verify"`
 }
 
 func (a adcmProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -47,14 +78,128 @@ func (a adcmProvider) Schema(_ context.Context, _ provider.SchemaRequest, respon
 				Optional:    true,
 			},
 			"login": schema.StringAttribute{
-				Description: "Login for ADCM API. May also be provided via ADCM_LOGIN environment variable.",
+				Description: "Login for ADCM API, required if token is not set. May also be provided via ADCM_LOGIN environment variable.",
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Password for ADCM API. May also be provided via ADCM_PASSWORD environment variable.",
+				Description: "Password for ADCM API, required if token is not set. May also be provided via ADCM_PASSWORD environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token": schema.StringAttribute{
+				Description: "Pre-issued token for ADCM API to use instead of signing in with login and password. " +
+					"May also be provided via ADCM_TOKEN environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Description: "Maximum number of attempts for requests failed with transient errors (409 of locked object, 429, 502, 503, 504), 1 disables retries. " +
+					"May also be provided via ADCM_RETRY_MAX_ATTEMPTS environment variable. Defaults to 4.",
+				Optional: true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Description: "Minimum backoff between attempts as duration string (e.g. \"1s\"). " +
+					"May also be provided via ADCM_RETRY_WAIT_MIN environment variable. Defaults to 1s.",
+				Optional: true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: "Maximum backoff between attempts as duration string (e.g. \"30s\"). " +
+					"May also be provided via ADCM_RETRY_WAIT_MAX environment variable. Defaults to 30s.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to PEM encoded CA bundle to trust in addition to system ones. " +
+					"May also be provided via ADCM_CA_CERT_FILE environment variable.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA bundle to trust in addition to system ones. " +
+					"May also be provided via ADCM_CA_CERT_PEM environment variable.",
+				Optional: true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate or path to it for mutual TLS. " +
+					"May also be provided via ADCM_CLIENT_CERT environment variable.",
+				Optional: true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded client key or path to it for mutual TLS. " +
+					"May also be provided via ADCM_CLIENT_KEY environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Disable verification of ADCM server certificate. " +
+					"May also be provided via ADCM_INSECURE_SKIP_VERIFY environment variable.",
+				Optional: true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout of a single ADCM API request as duration string (e.g. \"10s\"), \"0s\" disables the limit. " +
+					"May also be provided via ADCM_REQUEST_TIMEOUT environment variable. Defaults to 10s.",
+				Optional: true,
+			},
+			"upload_timeout": schema.StringAttribute{
+				Description: "Timeout of bundle download and upload to ADCM as positive duration string (e.g. \"30m\"). " +
+					"May also be provided via ADCM_UPLOAD_TIMEOUT environment variable. Defaults to 30m.",
+				Optional: true,
+			},
+			"task_timeout": schema.StringAttribute{
+				Description: "Timeout of waiting for ADCM task to finish as positive duration string (e.g. \"1h\"). " +
+					"May also be provided via ADCM_TASK_TIMEOUT environment variable. Defaults to 1h.",
+				Optional: true,
+			},
+			"task_poll_interval": schema.StringAttribute{
+				Description: "Interval between checks of ADCM task status as positive duration string (e.g. \"10s\"). " +
+					"May also be provided via ADCM_TASK_POLL_INTERVAL environment variable. Defaults to 10s.",
+				Optional: true,
+			},
+			"cancel_on_interrupt": schema.BoolAttribute{
+				Description: "Cancel running ADCM task if Terraform is interrupted while waiting for it. " +
+					"May also be provided via ADCM_CANCEL_ON_INTERRUPT environment variable. Defaults to true.",
+				Optional: true,
+			},
+			"log_level": schema.StringAttribute{
+				Description: "Level of Terraform logs to stream ADCM job logs with while actions run, " +
+					"one of trace, debug, info, warn, error or off to disable streaming. " +
+					"ADCM API has no way to read the tail of a log, so every poll downloads full stdout and stderr logs of running jobs, " +
+					"which may be expensive for long actions with verbose logs. " +
+					"May also be provided via ADCM_LOG_LEVEL environment variable. Defaults to off.",
+				Optional: true,
+			},
+			"task_events": schema.BoolAttribute{
+				Description: "Subscribe to ADCM event websocket to notice finished tasks without waiting for the next poll, " +
+					"polling is used if the websocket is not available. Only plain http proxies are supported for the websocket, " +
+					"tasks are polled behind https or socks proxies. " +
+					"May also be provided via ADCM_TASK_EVENTS environment variable. Defaults to false.",
+				Optional: true,
+			},
+			"bundle_mirror": schema.StringAttribute{
+				Description: "Base URL of mirror replacing scheme and host of http(s) bundle URLs, " +
+					"e.g. https://repo.example.com/bundles/b.tgz is downloaded from https://mirror.local/adcm/bundles/b.tgz with https://mirror.local/adcm mirror. " +
+					"May also be provided via ADCM_BUNDLE_MIRROR environment variable.",
+				Optional: true,
+			},
+			"download_retry_max_attempts": schema.Int64Attribute{
+				Description: "Maximum number of attempts for bundle downloads failed with transient errors (429, 5xx, timeouts, refused or reset connections), 1 disables retries. " +
+					"Backoff between attempts is the same as for ADCM API. " +
+					"May also be provided via ADCM_DOWNLOAD_RETRY_MAX_ATTEMPTS environment variable. Defaults to 4.",
+				Optional: true,
+			},
+			"download_ca_cert_file": schema.StringAttribute{
+				Description: "Path to PEM encoded CA bundle to trust in addition to system ones for bundle downloads. " +
+					"May also be provided via ADCM_DOWNLOAD_CA_CERT_FILE environment variable.",
+				Optional: true,
+			},
+			"download_ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA bundle to trust in addition to system ones for bundle downloads. " +
+					"May also be provided via ADCM_DOWNLOAD_CA_CERT_PEM environment variable.",
+				Optional: true,
+			},
+			"download_insecure_skip_verify": schema.BoolAttribute{
+				Description: "Disable verification of certificates of bundle servers. " +
+					"May also be provided via ADCM_DOWNLOAD_INSECURE_SKIP_VERIFY environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -100,6 +245,15 @@ func (a adcmProvider) Configure(ctx context.Context, request provider.ConfigureR
 		)
 	}
 
+	if config.Token.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown ADCM API token",
+			"The provider cannot create the ADCM API client as there is an unknown configuration value for the ADCM API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ADCM_TOKEN environment variable.",
+		)
+	}
+
 	if response.Diagnostics.HasError() {
 		return
 	}
@@ -110,6 +264,7 @@ func (a adcmProvider) Configure(ctx context.Context, request provider.ConfigureR
 	url := os.Getenv("ADCM_URL")
 	login := os.Getenv("ADCM_LOGIN")
 	password := os.Getenv("ADCM_PASSWORD")
+	token := os.Getenv("ADCM_TOKEN")
 
 	if !config.Url.IsNull() {
 		url = config.Url.ValueString()
@@ -123,6 +278,10 @@ func (a adcmProvider) Configure(ctx context.Context, request provider.ConfigureR
 		password = config.Password.ValueString()
 	}
 
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -136,22 +295,25 @@ func (a adcmProvider) Configure(ctx context.Context, request provider.ConfigureR
 		)
 	}
 
-	if login == "" {
+	// Login and password are required only if token is not provided
+	if token == "" && login == "" {
 		response.Diagnostics.AddAttributeError(
 			path.Root("login"),
 			"Missing ADCM API login",
 			"The provider cannot create the ADCM API client as there is a missing or empty value for the ADCM API login. "+
-				"Set the username value in the configuration or use the ADCM_LOGIN environment variable. "+
+				"Set the username value in the configuration or use the ADCM_LOGIN environment variable, "+
+				"or provide the ADCM API token instead. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if password == "" {
+	if token == "" && password == "" {
 		response.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing ADCM API password",
 			"The provider cannot create the ADCM API client as there is a missing or empty value for the ADCM API password. "+
-				"Set the password value in the configuration or use the ADCM_PASSWORD environment variable. "+
+				"Set the password value in the configuration or use the ADCM_PASSWORD environment variable, "+
+				"or provide the ADCM API token instead. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -160,15 +322,192 @@ func (a adcmProvider) Configure(ctx context.Context, request provider.ConfigureR
 		return
 	}
 
+	retry := adcmClient.DefaultRetryConfig
+	if value := os.Getenv("ADCM_RETRY_MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("retry_max_attempts"),
+				"Invalid ADCM API retry max attempts",
+				"The provider cannot parse ADCM_RETRY_MAX_ATTEMPTS environment variable: "+err.Error(),
+			)
+		}
+		retry.MaxAttempts = maxAttempts
+	}
+	if !config.RetryMaxAttempts.IsNull() {
+		retry.MaxAttempts = int(config.RetryMaxAttempts.ValueInt64())
+	}
+	if retry.MaxAttempts < 1 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_max_attempts"),
+			"Invalid ADCM API retry max attempts",
+			"The provider cannot create the ADCM API client as retry max attempts must be at least 1.",
+		)
+	}
+	retry.WaitMin = parseDurationSetting(config.RetryWaitMin, "ADCM_RETRY_WAIT_MIN", retry.WaitMin, path.Root("retry_wait_min"), &response.Diagnostics)
+	retry.WaitMax = parseDurationSetting(config.RetryWaitMax, "ADCM_RETRY_WAIT_MAX", retry.WaitMax, path.Root("retry_wait_max"), &response.Diagnostics)
+
+	timeouts := adcmClient.DefaultTimeouts
+	timeouts.Request = parseDurationSetting(config.RequestTimeout, "ADCM_REQUEST_TIMEOUT", timeouts.Request, path.Root("request_timeout"), &response.Diagnostics)
+	if timeouts.Request < 0 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid duration",
+			"The provider cannot use negative request timeout, 0 disables the limit.",
+		)
+	}
+	timeouts.Upload = parsePositiveDurationSetting(config.UploadTimeout, "ADCM_UPLOAD_TIMEOUT", timeouts.Upload, path.Root("upload_timeout"), &response.Diagnostics)
+	timeouts.Task = parsePositiveDurationSetting(config.TaskTimeout, "ADCM_TASK_TIMEOUT", timeouts.Task, path.Root("task_timeout"), &response.Diagnostics)
+
+	taskWait := adcmClient.DefaultTaskWaitConfig
+	taskWait.PollInterval = parsePositiveDurationSetting(config.TaskPollInterval, "ADCM_TASK_POLL_INTERVAL", taskWait.PollInterval, path.Root("task_poll_interval"), &response.Diagnostics)
+	if value := os.Getenv("ADCM_CANCEL_ON_INTERRUPT"); value != "" {
+		cancelOnInterrupt, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("cancel_on_interrupt"),
+				"Invalid ADCM task cancel on interrupt",
+				"The provider cannot parse ADCM_CANCEL_ON_INTERRUPT environment variable: "+err.Error(),
+			)
+		}
+		taskWait.CancelOnInterrupt = cancelOnInterrupt
+	}
+	if !config.CancelOnInterrupt.IsNull() {
+		taskWait.CancelOnInterrupt = config.CancelOnInterrupt.ValueBool()
+	}
+	logLevel := stringSetting(config.LogLevel, "ADCM_LOG_LEVEL")
+	if logLevel == "" {
+		logLevel = "off"
+	}
+	logHandler, err := jobLogHandler(logLevel)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("log_level"),
+			"Invalid ADCM job log level",
+			err.Error(),
+		)
+	}
+	taskWait.LogHandler = logHandler
+	if value := os.Getenv("ADCM_TASK_EVENTS"); value != "" {
+		taskEvents, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("task_events"),
+				"Invalid ADCM task events",
+				"The provider cannot parse ADCM_TASK_EVENTS environment variable: "+err.Error(),
+			)
+		}
+		taskWait.Events = taskEvents
+	}
+	if !config.TaskEvents.IsNull() {
+		taskWait.Events = config.TaskEvents.ValueBool()
+	}
+
+	tlsSettings := adcmClient.TLSSettings{
+		CACertFile: stringSetting(config.CACertFile, "ADCM_CA_CERT_FILE"),
+		CACertPEM:  stringSetting(config.CACertPEM, "ADCM_CA_CERT_PEM"),
+		ClientCert: stringSetting(config.ClientCert, "ADCM_CLIENT_CERT"),
+		ClientKey:  stringSetting(config.ClientKey, "ADCM_CLIENT_KEY"),
+	}
+	if value := os.Getenv("ADCM_INSECURE_SKIP_VERIFY"); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid ADCM API insecure skip verify",
+				"The provider cannot parse ADCM_INSECURE_SKIP_VERIFY environment variable: "+err.Error(),
+			)
+		}
+		tlsSettings.InsecureSkipVerify = insecure
+	}
+	if !config.InsecureSkipVerify.IsNull() {
+		tlsSettings.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+	tlsConfig, err := adcmClient.NewTLSConfig(tlsSettings)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Invalid ADCM API TLS configuration",
+			"The provider cannot create the ADCM API client TLS configuration: "+err.Error(),
+		)
+	}
+
+	download := adcmClient.DefaultDownloadConfig
+	download.Mirror = stringSetting(config.BundleMirror, "ADCM_BUNDLE_MIRROR")
+	download.Retry = retry
+	download.Retry.MaxAttempts = adcmClient.DefaultDownloadConfig.Retry.MaxAttempts
+	if value := os.Getenv("ADCM_DOWNLOAD_RETRY_MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("download_retry_max_attempts"),
+				"Invalid bundle download retry max attempts",
+				"The provider cannot parse ADCM_DOWNLOAD_RETRY_MAX_ATTEMPTS environment variable: "+err.Error(),
+			)
+		}
+		download.Retry.MaxAttempts = maxAttempts
+	}
+	if !config.DownloadRetryMaxAttempts.IsNull() {
+		download.Retry.MaxAttempts = int(config.DownloadRetryMaxAttempts.ValueInt64())
+	}
+	if download.Retry.MaxAttempts < 1 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("download_retry_max_attempts"),
+			"Invalid bundle download retry max attempts",
+			"The provider cannot create the ADCM API client as bundle download retry max attempts must be at least 1.",
+		)
+	}
+	downloadTLSSettings := adcmClient.TLSSettings{
+		CACertFile: stringSetting(config.DownloadCACertFile, "ADCM_DOWNLOAD_CA_CERT_FILE"),
+		CACertPEM:  stringSetting(config.DownloadCACertPEM, "ADCM_DOWNLOAD_CA_CERT_PEM"),
+	}
+	if value := os.Getenv("ADCM_DOWNLOAD_INSECURE_SKIP_VERIFY"); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("download_insecure_skip_verify"),
+				"Invalid bundle download insecure skip verify",
+				"The provider cannot parse ADCM_DOWNLOAD_INSECURE_SKIP_VERIFY environment variable: "+err.Error(),
+			)
+		}
+		downloadTLSSettings.InsecureSkipVerify = insecure
+	}
+	if !config.DownloadInsecureSkipVerify.IsNull() {
+		downloadTLSSettings.InsecureSkipVerify = config.DownloadInsecureSkipVerify.ValueBool()
+	}
+	download.TLSConfig, err = adcmClient.NewTLSConfig(downloadTLSSettings)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Invalid bundle download TLS configuration",
+			"The provider cannot create TLS configuration of bundle downloads: "+err.Error(),
+		)
+	}
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	ctx = tflog.SetField(ctx, "adcm_url", url)
 	ctx = tflog.SetField(ctx, "adcm_login", login)
 	ctx = tflog.SetField(ctx, "adcm_password", password)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "adcm_password")
+	ctx = tflog.SetField(ctx, "adcm_token", token)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "adcm_password", "adcm_token")
 
 	tflog.Debug(ctx, "Creating ADCM client")
 
 	// Create a new ADCM client using the configuration values
-	client, err := adcmClient.NewClient(&url, &login, &password)
+	opts := []adcmClient.Option{adcmClient.WithRetry(retry), adcmClient.WithTLSConfig(tlsConfig), adcmClient.WithTimeouts(timeouts), adcmClient.WithTaskWait(taskWait), adcmClient.WithDownload(download)}
+	var loginPtr, passwordPtr *string
+	if login != "" && password != "" {
+		loginPtr, passwordPtr = &login, &password
+	}
+	if token != "" {
+		opts = append(opts, adcmClient.WithToken(token))
+	}
+	client, err := adcmClient.NewClient(ctx, &url, loginPtr, passwordPtr, opts...)
+	if err == nil && token != "" {
+		// Fail fast on bad token instead of in the middle of apply
+		err = client.CheckAuth(ctx)
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Unable to Create ADCM API Client",
@@ -200,5 +539,76 @@ func (a adcmProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewClusterResource,
 		NewBundleResource,
 		NewProviderResource,
+		NewActionResource,
+	}
+}
+
+// stringSetting returns configuration value or environment variable if not set.
+func stringSetting(value types.String, envName string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(envName)
+}
+
+// parseDurationSetting returns duration from configuration value, environment variable or default value.
+func parseDurationSetting(value types.String, envName string, defaultValue time.Duration, attributePath path.Path, diags *diag.Diagnostics) time.Duration {
+	setting := stringSetting(value, envName)
+	if setting == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(setting)
+	if err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			fmt.Sprintf("The provider cannot parse duration %q (may also be provided via %s environment variable): %s", setting, envName, err),
+		)
+		return defaultValue
+	}
+	return duration
+}
+
+// parsePositiveDurationSetting is parseDurationSetting rejecting zero and negative durations.
+func parsePositiveDurationSetting(value types.String, envName string, defaultValue time.Duration, attributePath path.Path, diags *diag.Diagnostics) time.Duration {
+	duration := parseDurationSetting(value, envName, defaultValue, attributePath, diags)
+	if duration <= 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			fmt.Sprintf("The provider cannot use duration %s (may also be provided via %s environment variable) as it must be positive.", duration, envName),
+		)
+		return defaultValue
+	}
+	return duration
+}
+
+// jobLogHandler returns handler streaming ADCM job logs to Terraform logs with the level given.
+func jobLogHandler(level string) (adcmClient.JobLogHandler, error) {
+	var logFunc func(ctx context.Context, msg string, additionalFields ...map[string]interface{})
+	switch strings.ToLower(level) {
+	case "off":
+		return nil, nil
+	case "trace":
+		logFunc = tflog.Trace
+	case "debug":
+		logFunc = tflog.Debug
+	case "info":
+		logFunc = tflog.Info
+	case "warn":
+		logFunc = tflog.Warn
+	case "error":
+		logFunc = tflog.Error
+	default:
+		return nil, fmt.Errorf("unknown log level %q, expected one of trace, debug, info, warn, error, off", level)
 	}
+	return func(ctx context.Context, line adcmClient.JobLogLine) {
+		logFunc(ctx, line.Line, map[string]interface{}{
+			"task_id": line.TaskID,
+			"job_id":  line.JobID,
+			"job":     line.Job,
+			"action":  line.Action,
+			"log":     line.Log + "/" + line.Type,
+		})
+	}, nil
 }
@@ -97,7 +97,7 @@ func (d *providerDataSource) Read(ctx context.Context, req datasource.ReadReques
 		State:       requestOptions.State.ValueString(),
 	}
 
-	provider, err := d.client.GetProvider(opts)
+	provider, err := d.client.GetProvider(ctx, opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read ADCM Provider",
@@ -3,6 +3,7 @@ package adcm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -69,6 +71,9 @@ func (r *providerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"bundle_id": schema.Int64Attribute{
 				Description: "Bundle ID of provider.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"config": schema.StringAttribute{
 				Description: "Config of provider in JSON string to apply.",
@@ -98,23 +103,17 @@ func (r *providerResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Generate API request body from plan
-	var provider adcmClient.Provider
-	provider.BundleID = plan.BundleID.ValueInt64()
-	provider.Name = plan.Name.ValueString()
-	provider.Description = plan.Description.ValueString()
-	if plan.Config.ValueString() != "" {
-		err := json.Unmarshal([]byte(plan.Config.ValueString()), &provider.ProviderConfig.Config)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating provider",
-				"Could not unmarshal provider config of provider, unexpected error: "+err.Error(),
-			)
-			return
-		}
+	provider, err := expandProvider(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating provider",
+			err.Error(),
+		)
+		return
 	}
 
 	// Create new provider
-	p, err := r.client.CreateProvider(provider)
+	p, err := r.client.CreateProvider(ctx, provider)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating provider",
@@ -145,7 +144,12 @@ func (r *providerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Get refreshed provider value from ADCM
-	h, err := r.client.GetProvider(adcmClient.ProviderSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	h, err := r.client.GetProvider(ctx, adcmClient.ProviderSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if errors.Is(err, adcmClient.ErrNotFound) {
+		tflog.Warn(ctx, "ADCM provider not found, removing from state", map[string]any{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading ADCM provider",
@@ -161,6 +165,24 @@ func (r *providerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		state.Description = types.StringValue(h.Description)
 	}
 	state.BundleID = types.Int64Value(h.BundleID)
+	if state.Config.ValueString() != "" {
+		config, err := r.client.GetProviderConfig(ctx, h.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM provider",
+				fmt.Sprintf("Could not read config of ADCM provider ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+		state.Config, err = refreshJSONConfig(state.Config, config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ADCM provider",
+				fmt.Sprintf("Could not refresh config of ADCM provider ID %d: %s", state.ID.ValueInt64(), err),
+			)
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -172,10 +194,56 @@ func (r *providerResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *providerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error Update ADCM provider",
-		fmt.Sprintf("%+v", req),
-	)
+	// Retrieve values from plan
+	var plan providerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state providerResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	provider, err := expandProvider(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating provider",
+			err.Error(),
+		)
+		return
+	}
+	provider.ID = state.ID.ValueInt64()
+	// secrets are encrypted by ADCM, so their changes are detected against state
+	if prior, err := expandProvider(state); err == nil {
+		provider.PriorConfig = prior.ProviderConfig.Config
+	}
+
+	// Update existing provider
+	p, err := r.client.UpdateProvider(ctx, provider)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating provider",
+			fmt.Sprintf("Could not update ADCM provider ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.Int64Value(p.ID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -189,8 +257,8 @@ func (r *providerResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	// Delete existing provider
-	err := r.client.DeleteProvider(adcmClient.ProviderSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
-	if err != nil {
+	err := r.client.DeleteProvider(ctx, adcmClient.ProviderSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if err != nil && !errors.Is(err, adcmClient.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting ADCM provider",
 			"Could not delete provider, unexpected error: "+err.Error(),
@@ -203,3 +271,18 @@ func (r *providerResource) ImportState(ctx context.Context, req resource.ImportS
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// expandProvider builds API request body from resource model.
+func expandProvider(model providerResourceModel) (adcmClient.Provider, error) {
+	var provider adcmClient.Provider
+	provider.BundleID = model.BundleID.ValueInt64()
+	provider.Name = model.Name.ValueString()
+	provider.Description = model.Description.ValueString()
+	if model.Config.ValueString() != "" {
+		err := json.Unmarshal([]byte(model.Config.ValueString()), &provider.ProviderConfig.Config)
+		if err != nil {
+			return provider, fmt.Errorf("could not unmarshal provider config of provider, unexpected error: %s", err)
+		}
+	}
+	return provider, nil
+}
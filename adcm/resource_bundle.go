@@ -2,6 +2,8 @@ package adcm
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
@@ -9,16 +11,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &bundleResource{}
-	_ resource.ResourceWithConfigure   = &bundleResource{}
-	_ resource.ResourceWithImportState = &bundleResource{}
+	_ resource.Resource                   = &bundleResource{}
+	_ resource.ResourceWithConfigure      = &bundleResource{}
+	_ resource.ResourceWithImportState    = &bundleResource{}
+	_ resource.ResourceWithValidateConfig = &bundleResource{}
 )
 
 // NewBundleResource is a helper function to simplify the provider implementation.
@@ -38,6 +44,18 @@ type bundleModel struct {
 	Version types.String `tfsdk:"version"`
 	Edition types.String `tfsdk:"edition"`
 	URL     types.String `tfsdk:"url"`
+	Path    types.String `tfsdk:"path"`
+	Content types.String `tfsdk:"content"`
+	SHA256  types.String `tfsdk:"sha256"`
+	Hash    types.String `tfsdk:"hash"`
+
+	DownloadHeaders  types.Map    `tfsdk:"download_headers"`
+	DownloadUsername types.String `tfsdk:"download_username"`
+	DownloadPassword types.String `tfsdk:"download_password"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+	DeleteAdopted types.Bool `tfsdk:"delete_adopted"`
+	Adopted       types.Bool `tfsdk:"adopted"`
 }
 
 // Metadata returns the data source type name.
@@ -70,13 +88,105 @@ func (r *bundleResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed:    true,
 			},
 			"url": schema.StringAttribute{
-				Description: "URL of bundle.",
-				Required:    true,
+				Description: "http(s) or file:// URL of bundle. Exactly one of url, path and content must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of bundle file on local filesystem. Exactly one of url, path and content must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Base64 encoded content of bundle, e.g. from filebase64(). Exactly one of url, path and content must be set. " +
+					"The whole content is kept in plan and state, so large bundles should be set with path and sha256 " +
+					"to keep only the checksum there.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"download_headers": schema.MapAttribute{
+				Description: "Headers of request downloading bundle from http(s) url, e.g. with bearer token.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"download_username": schema.StringAttribute{
+				Description: "Username of basic authentication downloading bundle from http(s) url.",
+				Optional:    true,
+			},
+			"download_password": schema.StringAttribute{
+				Description: "Password of basic authentication downloading bundle from http(s) url.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "Expected hex encoded SHA-256 checksum of bundle, verified during upload. " +
+					"Change of checksum replaces the bundle.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hash": schema.StringAttribute{
+				Description: "Hash of bundle computed by ADCM.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt the same bundle if it is loaded to ADCM already instead of failing.",
+				Optional:    true,
+			},
+			"delete_adopted": schema.BoolAttribute{
+				Description: "Delete adopted bundle from ADCM on destroy, it is only removed from the state by default.",
+				Optional:    true,
+			},
+			"adopted": schema.BoolAttribute{
+				Description: "Whether the bundle was loaded to ADCM before and adopted.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
+// ValidateConfig checks that exactly one source of bundle is set.
+func (r *bundleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config bundleModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := 0
+	for _, source := range []types.String{config.URL, config.Path, config.Content} {
+		// unknown values are checked once known
+		if source.IsUnknown() {
+			return
+		}
+		if !source.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid bundle source",
+			"Exactly one of url, path and content must be set.",
+		)
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (r *bundleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -96,7 +206,16 @@ func (r *bundleResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	bundle, err := r.client.UploadBundle(plan.URL.ValueString())
+	source, err := expandBundleSource(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating bundle",
+			err.Error(),
+		)
+		return
+	}
+
+	bundle, err := r.client.UploadBundle(ctx, source)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating bundle",
@@ -108,6 +227,11 @@ func (r *bundleResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.Name = types.StringValue(bundle.Name)
 	plan.Version = types.StringValue(bundle.Version)
 	plan.Edition = types.StringValue(bundle.Edition)
+	plan.Hash = types.StringValue(bundle.Hash)
+	plan.Adopted = types.BoolValue(bundle.Adopted)
+	if bundle.Adopted {
+		tflog.Info(ctx, "ADCM bundle is loaded already, adopting it", map[string]any{"id": bundle.ID})
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -127,7 +251,12 @@ func (r *bundleResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	bundle, err := r.client.GetBundle(adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	bundle, err := r.client.GetBundle(ctx, adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if errors.Is(err, adcmClient.ErrNotFound) {
+		tflog.Warn(ctx, "ADCM bundle not found, removing from state", map[string]any{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read ADCM Bundle",
@@ -140,6 +269,7 @@ func (r *bundleResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Name = types.StringValue(bundle.Name)
 	state.Edition = types.StringValue(bundle.Edition)
 	state.Version = types.StringValue(bundle.Version)
+	state.Hash = types.StringValue(bundle.Hash)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -150,11 +280,37 @@ func (r *bundleResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// Source of bundle requires replacement, so only settings of the resource itself are updated.
 func (r *bundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error Update ADCM bundle",
-		fmt.Sprintf("%+v", req),
-	)
+	// Retrieve values from plan
+	var plan bundleModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state bundleModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Name = state.Name
+	plan.Version = state.Version
+	plan.Edition = state.Edition
+	plan.Hash = state.Hash
+	plan.Adopted = state.Adopted
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -167,9 +323,14 @@ func (r *bundleResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if state.Adopted.ValueBool() && !state.DeleteAdopted.ValueBool() {
+		tflog.Info(ctx, "ADCM bundle was adopted, leaving it in ADCM", map[string]any{"id": state.ID.ValueInt64()})
+		return
+	}
+
 	// Delete existing bundle
-	err := r.client.DeleteBundle(adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
-	if err != nil {
+	err := r.client.DeleteBundle(ctx, adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: state.ID.ValueInt64()}})
+	if err != nil && !errors.Is(err, adcmClient.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting ADCM bundle",
 			"Could not delete bundle, unexpected error: "+err.Error(),
@@ -182,3 +343,29 @@ func (r *bundleResource) ImportState(ctx context.Context, req resource.ImportSta
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// expandBundleSource builds source of bundle upload from resource model.
+func expandBundleSource(ctx context.Context, model bundleModel) (adcmClient.BundleSource, error) {
+	source := adcmClient.BundleSource{
+		URL:           model.URL.ValueString(),
+		Path:          model.Path.ValueString(),
+		Username:      model.DownloadUsername.ValueString(),
+		Password:      model.DownloadPassword.ValueString(),
+		SHA256:        model.SHA256.ValueString(),
+		AdoptExisting: model.AdoptExisting.ValueBool(),
+	}
+	if !model.DownloadHeaders.IsNull() {
+		diags := model.DownloadHeaders.ElementsAs(ctx, &source.Headers, false)
+		if diags.HasError() {
+			return source, fmt.Errorf("could not read download headers of bundle")
+		}
+	}
+	if !model.Content.IsNull() {
+		content, err := base64.StdEncoding.DecodeString(model.Content.ValueString())
+		if err != nil {
+			return source, fmt.Errorf("could not decode base64 content of bundle, unexpected error: %s", err)
+		}
+		source.Content = content
+	}
+	return source, nil
+}
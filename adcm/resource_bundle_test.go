@@ -1,6 +1,7 @@
 package adcm
 
 import (
+	"context"
 	"fmt"
 	adcmClient "github.com/giggsoff/terraform-provider-adcm/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -42,7 +43,8 @@ func testAccCheckBundleExists(n string) resource.TestCheckFunc {
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("no ID is set")
 		}
-		client, err := adcmClient.NewClient(&ADCM_URL, &ADCM_LOGIN, &ADCM_PASSWORD)
+		ctx := context.Background()
+		client, err := adcmClient.NewClient(ctx, &ADCM_URL, &ADCM_LOGIN, &ADCM_PASSWORD)
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("failed to create adcmClient: %s", err)
 		}
@@ -50,7 +52,7 @@ func testAccCheckBundleExists(n string) resource.TestCheckFunc {
 		if err != nil {
 			return fmt.Errorf("failed to cinvert id: %s", err)
 		}
-		bundle, err := client.GetBundle(adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: ui64}})
+		bundle, err := client.GetBundle(ctx, adcmClient.BundleSearch{Identifier: adcmClient.Identifier{ID: ui64}})
 		if err != nil {
 			return fmt.Errorf("failed to find bundle: %s", err)
 		}
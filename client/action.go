@@ -0,0 +1,245 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/imdario/mergo"
+)
+
+// Types of ADCM objects actions can be run on
+const (
+	ActionObjectCluster   = "cluster"
+	ActionObjectService   = "service"
+	ActionObjectComponent = "component"
+	ActionObjectHost      = "host"
+	ActionObjectProvider  = "provider"
+	ActionObjectADCM      = "adcm"
+)
+
+// ActionObjectTypes - all types of ADCM objects actions can be run on
+var ActionObjectTypes = []string{
+	ActionObjectCluster,
+	ActionObjectService,
+	ActionObjectComponent,
+	ActionObjectHost,
+	ActionObjectProvider,
+	ActionObjectADCM,
+}
+
+// actionConfigSchema describes config of action
+type actionConfigSchema struct {
+	// Defaults holds default values of config fields
+	Defaults map[string]interface{}
+	// Fields maps names of fields to names of their subfields, subfields are nil for plain fields
+	Fields map[string]map[string]bool
+}
+
+// validate checks that config contains only fields known to the schema.
+// Values are not included in the error as they may be sensitive.
+func (s *actionConfigSchema) validate(config map[string]interface{}) error {
+	var unknown []string
+	for key, value := range config {
+		subfields, defined := s.Fields[key]
+		if !defined {
+			unknown = append(unknown, key)
+			continue
+		}
+		if subfields == nil {
+			continue
+		}
+		group, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("action config field %q is a group and must be an object", key)
+		}
+		for subKey := range group {
+			if !subfields[subKey] {
+				unknown = append(unknown, key+"/"+subKey)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown action config fields: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+type actionRun struct {
+	Config  map[string]interface{} `json:"config"`
+	HC      []map[string]int64     `json:"hc,omitempty"`
+	Verbose bool                   `json:"verbose"`
+}
+
+// actionObjectPath returns API path of object (e.g. "host/1").
+// ADCM object is single, so its ID is looked up if not set.
+func (c *Client) actionObjectPath(ctx context.Context, objectType string, objectID int64) (string, error) {
+	switch objectType {
+	case ActionObjectCluster, ActionObjectService, ActionObjectComponent, ActionObjectHost, ActionObjectProvider:
+	case ActionObjectADCM:
+		if objectID == 0 {
+			adcmIDs, err := getList[Identifier](ctx, c, fmt.Sprintf("%s/api/v1/adcm/", c.HostURL))
+			if err != nil {
+				return "", err
+			}
+			if len(adcmIDs) < 1 {
+				return "", fmt.Errorf("no adcm object found")
+			}
+			objectID = adcmIDs[0].ID
+		}
+	default:
+		return "", fmt.Errorf("unsupported object type %q", objectType)
+	}
+	return fmt.Sprintf("%s/%d", objectType, objectID), nil
+}
+
+func (c *Client) getActionID(ctx context.Context, objectPath string, actionName string) (int64, error) {
+	actionIDs, err := getList[Identifier](ctx, c, fmt.Sprintf("%s/api/v1/%s/action/?name=%s", c.HostURL, objectPath, url.QueryEscape(actionName)))
+	if err != nil {
+		return 0, err
+	}
+	if len(actionIDs) < 1 {
+		return 0, fmt.Errorf("no action %q found for %s: %w", actionName, objectPath, ErrNotFound)
+	}
+	return actionIDs[0].ID, nil
+}
+
+// getActionConfig returns config schema of action
+func (c *Client) getActionConfig(ctx context.Context, objectPath string, actionID int64) (*actionConfigSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/%s/action/%d/", c.HostURL, objectPath, actionID), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	var configSchema ClusterConfigResponse
+	err = json.Unmarshal(body, &configSchema)
+	if err != nil {
+		return nil, err
+	}
+	config := make(map[string]interface{})
+	fields := make(map[string]map[string]bool)
+	if nestedConfig, defined := configSchema.Config["config"]; defined {
+		nestedConfigParsed, ok := nestedConfig.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected config schema: not a list")
+		}
+		for _, elInt := range nestedConfigParsed {
+			el, ok := elInt.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unexpected config schema: not a map")
+			}
+			itemName, ok := el["name"]
+			if !ok {
+				return nil, fmt.Errorf("unexpected config schema: no name")
+			}
+			itemNameParsed, ok := itemName.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected config schema: name not string")
+			}
+			if itemType, defined := el["type"]; defined {
+				if itemTypeParsed, ok := itemType.(string); ok {
+					if itemTypeParsed == "group" {
+						config[itemNameParsed] = make(map[string]interface{})
+						fields[itemNameParsed] = make(map[string]bool)
+						continue
+					}
+				}
+			}
+			if itemSubName, defined := el["subname"]; defined && itemSubName != "" {
+				if itemSubNameParsed, ok := itemSubName.(string); ok {
+					if config[itemNameParsed] == nil {
+						config[itemNameParsed] = make(map[string]interface{})
+					}
+					config[itemNameParsed].(map[string]interface{})[itemSubNameParsed] = el["value"]
+					if fields[itemNameParsed] == nil {
+						fields[itemNameParsed] = make(map[string]bool)
+					}
+					fields[itemNameParsed][itemSubNameParsed] = true
+					continue
+				}
+			}
+			config[itemNameParsed] = el["value"]
+			if _, defined := fields[itemNameParsed]; !defined {
+				fields[itemNameParsed] = nil
+			}
+		}
+	}
+	return &actionConfigSchema{Defaults: config, Fields: fields}, nil
+}
+
+// startAction runs action of object located at objectPath and returns ID of the task created.
+// Config is validated against the action config schema and deep-merged over its default values.
+func (c *Client) startAction(ctx context.Context, objectPath string, actionName string, config map[string]interface{}, hc []HostComponent, verbose bool) (int64, error) {
+	actionID, err := c.getActionID(ctx, objectPath, actionName)
+	if err != nil {
+		return 0, err
+	}
+	configSchema, err := c.getActionConfig(ctx, objectPath, actionID)
+	if err != nil {
+		return 0, err
+	}
+	err = configSchema.validate(config)
+	if err != nil {
+		return 0, fmt.Errorf("action %q of %s: %w", actionName, objectPath, err)
+	}
+	run := actionRun{Config: configSchema.Defaults, Verbose: verbose}
+	if config != nil {
+		err = mergo.Merge(&run.Config, config, mergo.WithOverride)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, el := range hc {
+		run.HC = append(run.HC, map[string]int64{"host_id": el.HostID, "service_id": el.ServiceID, "component_id": el.ComponentID})
+	}
+	jsonValue, _ := json.Marshal(run)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/%s/action/%d/run/", c.HostURL, objectPath, actionID), bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Add("Content-Type", "application/json;charset=utf-8")
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return 0, err
+	}
+	var taskID Identifier
+	err = json.Unmarshal(body, &taskID)
+	if err != nil {
+		return 0, err
+	}
+	return taskID.ID, nil
+}
+
+// RunAction - run action on object of objectType (cluster, service, component, host, provider or adcm)
+// and wait for the task to finish. Config is deep-merged over the default values of action config,
+// hc sets host-component mapping for actions which change it.
+func (c *Client) RunAction(ctx context.Context, objectType string, objectID int64, actionName string, config map[string]interface{}, hc []HostComponent, verbose bool) (*TaskResponse, error) {
+	objectPath, err := c.actionObjectPath(ctx, objectType, objectID)
+	if err != nil {
+		return nil, err
+	}
+	taskID, err := c.startAction(ctx, objectPath, actionName, config, hc, verbose)
+	if err != nil {
+		return nil, err
+	}
+	task, err := c.waitTask(ctx, taskID, actionName)
+	var interrupted *TaskInterruptedError
+	if errors.As(err, &interrupted) {
+		interruptCtx, cancel := c.interruptContext()
+		defer cancel()
+		task = c.interruptTask(interruptCtx, interrupted)
+		interrupted.Object = objectPath
+		interrupted.ObjectState, _ = c.getObjectState(interruptCtx, objectPath)
+	}
+	return task, err
+}
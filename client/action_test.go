@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunAction(t *testing.T) {
+	var run actionRun
+	var runPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/adcm/":
+			_, _ = w.Write([]byte(`[{"id": 1}]`))
+		case "/api/v1/host/3/action/", "/api/v1/adcm/1/action/":
+			_, _ = w.Write([]byte(`[{"id": 7}]`))
+		case "/api/v1/host/3/action/7/", "/api/v1/adcm/1/action/7/":
+			_, _ = w.Write([]byte(`{"id": 7, "config": {"config": [
+				{"name": "rolename", "type": "string", "value": "admin"},
+				{"name": "limits", "type": "group", "subname": ""},
+				{"name": "limits", "subname": "count", "type": "integer", "value": 1}
+			]}}`))
+		case "/api/v1/host/3/action/7/run/", "/api/v1/adcm/1/action/7/run/":
+			runPath = r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&run); err != nil {
+				t.Error(err)
+			}
+			_, _ = w.Write([]byte(`{"id": 42}`))
+		case "/api/v1/task/42/":
+			_, _ = w.Write([]byte(`{"id": 42, "status": "success"}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := c.RunAction(context.Background(), ActionObjectHost, 3, "statuschecker",
+		map[string]interface{}{"limits": map[string]interface{}{"count": 3}}, []HostComponent{{HostID: 3, ServiceID: 1, ComponentID: 2}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.ID != 42 || task.Status != "success" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+	expectedConfig := map[string]interface{}{"rolename": "admin", "limits": map[string]interface{}{"count": float64(3)}}
+	if !reflect.DeepEqual(run.Config, expectedConfig) {
+		t.Errorf("Unexpected action config: %v", run.Config)
+	}
+	if !reflect.DeepEqual(run.HC, []map[string]int64{{"host_id": 3, "service_id": 1, "component_id": 2}}) || !run.Verbose {
+		t.Errorf("Unexpected action run: %v", run)
+	}
+
+	_, err = c.RunAction(context.Background(), ActionObjectADCM, 0, "statuschecker", nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runPath != "/api/v1/adcm/1/action/7/run/" {
+		t.Errorf("Unexpected action run path: %s", runPath)
+	}
+
+	_, err = c.RunAction(context.Background(), ActionObjectHost, 3, "statuschecker",
+		map[string]interface{}{"rolepass": "secret", "limits": map[string]interface{}{"size": 1}}, nil, false)
+	if err == nil || !strings.Contains(err.Error(), "limits/size, rolepass") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	_, err = c.RunAction(context.Background(), "bundle", 1, "statuschecker", nil, nil, false)
+	if err == nil {
+		t.Error("Unsupported object type is accepted")
+	}
+}
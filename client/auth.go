@@ -1,44 +1,61 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+// WithToken - use pre-issued token instead of signing in with username and password
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.Token = token
+	}
+}
+
+// CheckAuth - check that client is authenticated with a cheap request
+func (c *Client) CheckAuth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/adcm/", c.HostURL), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, nil)
+	if err != nil {
+		return fmt.Errorf("authentication check failed: %w", err)
+	}
+	return nil
+}
+
 // SignIn - Get a new token for user
-func (c *Client) SignIn() (*AuthResponse, error) {
+func (c *Client) SignIn(ctx context.Context) (*AuthResponse, error) {
 	if c.Auth.Username == "" || c.Auth.Password == "" {
 		return nil, fmt.Errorf("define username and password")
 	}
 
-	resp, err := http.PostForm(fmt.Sprintf("%s/api/v1/token/", c.HostURL),
-		url.Values{
-			"username": {c.Auth.Username},
-			"password": {c.Auth.Password},
-		})
+	form := url.Values{
+		"username": {c.Auth.Username},
+		"password": {c.Auth.Password},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/token/", c.HostURL), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, body, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("getToken: http status: %s", resp.Status)
-		body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPostSize))
-		if err == nil {
-			log.Printf("getToken: POST body: '%s'", body)
-		}
+		log.Printf("getToken: POST body: '%s'", body)
 		return nil, fmt.Errorf("wrong responce status: %s", resp.Status)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPostSize))
-	if err != nil {
-		return nil, fmt.Errorf("getToken: body read error: %s", err)
-	}
-
 	ar := AuthResponse{}
 	err = json.Unmarshal(body, &ar)
 	if err != nil {
@@ -47,3 +64,25 @@ func (c *Client) SignIn() (*AuthResponse, error) {
 
 	return &ar, nil
 }
+
+func (c *Client) currentToken() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.Token
+}
+
+// reauthenticate gets a new token unless the rejected one was already renewed
+// by a concurrent request, so parallel operations sign in only once.
+func (c *Client) reauthenticate(ctx context.Context, rejectedToken string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.Token != rejectedToken {
+		return nil
+	}
+	ar, err := c.SignIn(ctx)
+	if err != nil {
+		return err
+	}
+	c.Token = ar.Token
+	return nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReauthentication(t *testing.T) {
+	var signIns int32
+	var validToken atomic.Value
+	validToken.Store("token-1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/token/" {
+			n := atomic.AddInt32(&signIns, 1)
+			validToken.Store(fmt.Sprintf("token-%d", n))
+			_, _ = fmt.Fprintf(w, `{"token": "token-%d"}`, n)
+			return
+		}
+		if r.Header.Get("Authorization") != "Token "+validToken.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail": "Invalid token."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	login, password := "admin", "admin"
+	c, err := NewClient(context.Background(), &server.URL, &login, &password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// session is rotated on the server side
+	validToken.Store("revoked")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetBundles(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if signIns != 2 {
+		t.Errorf("Unexpected sign in count: %d", signIns)
+	}
+	if c.currentToken() != "token-2" {
+		t.Errorf("Token was not renewed: %s", c.currentToken())
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	var signIns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/token/" {
+			atomic.AddInt32(&signIns, 1)
+		}
+		if r.Header.Get("Authorization") != "Token pre-issued" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail": "Invalid token."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithToken("pre-issued"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CheckAuth(context.Background()); err != nil {
+		t.Error(err)
+	}
+
+	c, err = NewClient(context.Background(), &server.URL, nil, nil, WithToken("bad"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CheckAuth(context.Background()); err == nil {
+		t.Error("Bad token passed authentication check")
+	}
+	if signIns != 0 {
+		t.Errorf("Unexpected sign in with token: %d", signIns)
+	}
+}
@@ -2,28 +2,27 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 )
 
 // GetBundles - Returns list of bundles
-func (c *Client) GetBundles() ([]Bundle, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/stack/bundle", c.HostURL), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var bundles []Bundle
-	err = unwrapResults(body, &bundles)
+func (c *Client) GetBundles(ctx context.Context) ([]Bundle, error) {
+	bundles, err := getList[Bundle](ctx, c, fmt.Sprintf("%s/api/v1/stack/bundle/", c.HostURL))
 	if err != nil {
 		return nil, err
 	}
@@ -32,8 +31,8 @@ func (c *Client) GetBundles() ([]Bundle, error) {
 }
 
 // GetBundle - Returns bundle
-func (c *Client) GetBundle(searchOpts BundleSearch) (*Bundle, error) {
-	bundles, err := c.GetBundles()
+func (c *Client) GetBundle(ctx context.Context, searchOpts BundleSearch) (*Bundle, error) {
+	bundles, err := c.GetBundles(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -63,46 +62,73 @@ func (c *Client) GetBundle(searchOpts BundleSearch) (*Bundle, error) {
 		res = append(res, b)
 	}
 	if len(res) == 0 {
-		return nil, fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+		return nil, fmt.Errorf("%w. Please change your search criteria and try again", ErrNotFound)
 	}
 	if len(res) > 1 {
-		return nil, fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+		return nil, fmt.Errorf("%w. Please try a more specific search criteria", ErrAmbiguous)
 	}
 	return &res[0], nil
 }
 
-func (c *Client) UploadBundle(url string) (*Bundle, error) {
-	bundleFileName := path.Base(url)
-	response, err := http.Get(url)
+// writeBundleFile writes multipart form with bundle file read from content
+func writeBundleFile(m *multipart.Writer, fileName string, content io.Reader) error {
+	part, err := m.CreateFormFile("file", fileName)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	size, err := io.Copy(part, content)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return fmt.Errorf("bundle is empty")
 	}
+	return m.Close()
+}
+
+// uploadBundleFile streams bundle content to ADCM as file with fileName.
+// Errors of reading content are reported together with the error of ADCM.
+func (c *Client) uploadBundleFile(ctx context.Context, fileName string, content io.Reader) error {
 	r, w := io.Pipe()
 	m := multipart.NewWriter(w)
+	written := make(chan error, 1)
 	go func() {
-		defer w.Close()
-		defer m.Close()
-		defer response.Body.Close()
-		part, err := m.CreateFormFile("file", bundleFileName)
-		if err != nil {
-			return
-		}
-		if _, err = io.Copy(part, response.Body); err != nil {
-			return
-		}
+		err := writeBundleFile(m, fileName, content)
+		// request body ends with the error if content can not be read
+		_ = w.CloseWithError(err)
+		written <- err
 	}()
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/stack/upload/", c.HostURL), r)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/stack/upload/", c.HostURL), r)
 	if err != nil {
-		return nil, err
+		_ = r.Close()
+		<-written
+		return err
 	}
 	req.Header.Add("Content-Type", m.FormDataContentType())
 
 	_, err = c.doRequest(req, nil)
+	// unblock writing if ADCM stopped reading the body
+	_ = r.Close()
+	writeErr := <-written
+	if errors.Is(writeErr, io.ErrClosedPipe) {
+		writeErr = nil
+	}
+	switch {
+	case writeErr != nil && err != nil:
+		return fmt.Errorf("could not read bundle: %s; ADCM responded: %w", writeErr, err)
+	case writeErr != nil:
+		return fmt.Errorf("could not read bundle: %w", writeErr)
+	}
+	return err
+}
+
+// loadBundle loads bundle uploaded to ADCM as file with fileName
+func (c *Client) loadBundle(ctx context.Context, fileName string) (*Bundle, error) {
+	data, err := json.Marshal(map[string]interface{}{"bundle_file": fileName})
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(map[string]interface{}{"bundle_file": bundleFileName})
-	req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/v1/stack/load/", c.HostURL), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/stack/load/", c.HostURL), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -117,16 +143,152 @@ func (c *Client) UploadBundle(url string) (*Bundle, error) {
 	if err != nil {
 		return nil, err
 	}
-	return c.GetBundle(BundleSearch{Identifier: id})
+	return c.GetBundle(ctx, BundleSearch{Identifier: id})
+}
+
+// BundleSource - source of bundle to upload, exactly one of URL, Path and Content must be set
+type BundleSource struct {
+	// URL is http(s) or file:// URL of bundle
+	URL string
+	// Path is path of bundle file on local filesystem
+	Path string
+	// Content is content of bundle, it is uploaded as file with FileName
+	Content  []byte
+	FileName string
+	// Headers are added to the request downloading bundle from http(s) URL
+	Headers map[string]string
+	// Username and Password are used for basic authentication of bundle download if set
+	Username string
+	Password string
+	// SHA256 is hex encoded checksum of bundle verified during upload if set
+	SHA256 string
+	// AdoptExisting enables adoption of the same bundle if it is loaded to ADCM already
+	AdoptExisting bool
+}
+
+// sha256Reader verifies SHA-256 checksum of content once it is read completely
+type sha256Reader struct {
+	r        io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func (r *sha256Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); !strings.EqualFold(actual, r.expected) {
+			return n, fmt.Errorf("sha256 checksum mismatch: expected %s, got %s", r.expected, actual)
+		}
+	}
+	return n, err
+}
+
+// openBundle opens bundle content of source and returns name of file to upload it as
+func (c *Client) openBundle(ctx context.Context, source BundleSource) (io.ReadCloser, string, error) {
+	set := 0
+	for _, defined := range []bool{source.URL != "", source.Path != "", source.Content != nil} {
+		if defined {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, "", fmt.Errorf("exactly one of bundle url, path and content must be set")
+	}
+	switch {
+	case source.Content != nil:
+		fileName := source.FileName
+		if fileName == "" {
+			fileName = "bundle.tgz"
+		}
+		return io.NopCloser(bytes.NewReader(source.Content)), fileName, nil
+	case source.URL != "":
+		bundleURL, err := url.Parse(source.URL)
+		if err != nil {
+			return nil, "", err
+		}
+		switch bundleURL.Scheme {
+		case "http", "https":
+			content, err := c.downloadBundle(ctx, source)
+			if err != nil {
+				return nil, "", err
+			}
+			return content, path.Base(bundleURL.Path), nil
+		case "file":
+			return c.openBundle(ctx, BundleSource{Path: bundleURL.Path})
+		default:
+			return nil, "", fmt.Errorf("unsupported scheme of bundle url %s", source.URL)
+		}
+	default:
+		file, err := os.Open(source.Path)
+		if err != nil {
+			return nil, "", err
+		}
+		return file, filepath.Base(source.Path), nil
+	}
+}
+
+// findBundleByHash returns bundle with ADCM hash given
+func (c *Client) findBundleByHash(ctx context.Context, hash string) (*Bundle, error) {
+	bundles, err := c.GetBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bundles {
+		if b.Hash == hash {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("bundle with hash %s: %w", hash, ErrNotFound)
+}
+
+// UploadBundle - upload bundle from source to ADCM and load it, bundle is not loaded if its checksum does not match.
+// If AdoptExisting is set, the same bundle loaded to ADCM before is returned instead of load error.
+func (c *Client) UploadBundle(ctx context.Context, source BundleSource) (*Bundle, error) {
+	// Bundle transfer may be long, so it is limited with upload timeout instead of request one
+	uploadCtx, cancel := context.WithTimeout(ctx, c.Timeouts.Upload)
+	defer cancel()
+	uploadCtx = withRequestTimeout(uploadCtx, 0)
+	content, bundleFileName, err := c.openBundle(uploadCtx, source)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	// ADCM identifies bundles with SHA-1 hash of archive
+	adcmHash := sha1.New()
+	var reader io.Reader = io.TeeReader(content, adcmHash)
+	if source.SHA256 != "" {
+		reader = &sha256Reader{r: reader, hash: sha256.New(), expected: source.SHA256}
+	}
+	err = c.uploadBundleFile(uploadCtx, bundleFileName, reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not upload bundle %s: %w", bundleFileName, err)
+	}
+	// ADCM removes unpacked files of bundle failed to load by itself. Uploaded archive
+	// can not be removed with API, so the error reports it is left in ADCM until the next
+	// upload of the same file overwrites it.
+	bundle, err := c.loadBundle(ctx, bundleFileName)
+	if err != nil && source.AdoptExisting {
+		// load fails if the same bundle is loaded already
+		existing, findErr := c.findBundleByHash(ctx, hex.EncodeToString(adcmHash.Sum(nil)))
+		if findErr == nil {
+			existing.Adopted = true
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load bundle %s (uploaded archive is left in ADCM as its API can not remove it): %w", bundleFileName, err)
+	}
+	return bundle, nil
 }
 
 // DeleteBundle - Delete bundle
-func (c *Client) DeleteBundle(searchOpts BundleSearch) error {
-	bundle, err := c.GetBundle(searchOpts)
+func (c *Client) DeleteBundle(ctx context.Context, searchOpts BundleSearch) error {
+	bundle, err := c.GetBundle(ctx, searchOpts)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/stack/bundle/%d/", c.HostURL, bundle.ID), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v1/stack/bundle/%d/", c.HostURL, bundle.ID), nil)
 	if err != nil {
 		return err
 	}
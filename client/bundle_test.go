@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUploadBundle(t *testing.T) {
+	var uploaded string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bundles/good.tgz":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write([]byte("bundle"))
+		case "/bundles/missing.tgz":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("<html>not found</html>"))
+		case "/bundles/page.tgz":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>login</html>"))
+		case "/bundles/empty.tgz":
+			w.Header().Set("Content-Type", "application/gzip")
+		case "/bundles/broken.tgz":
+			w.Header().Set("Content-Type", "application/gzip")
+			w.Header().Set("Content-Length", "100")
+			_, _ = w.Write([]byte("bund"))
+		case "/bundles/invalid.tgz":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write([]byte("invalid"))
+		case "/api/v1/stack/upload/":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"code": "UPLOAD_ERROR", "desc": "broken upload"}`))
+				return
+			}
+			content, _ := io.ReadAll(file)
+			uploaded = header.Filename + ":" + string(content)
+			w.WriteHeader(http.StatusCreated)
+		case "/api/v1/stack/load/":
+			if strings.HasPrefix(uploaded, "dup.tgz") {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"code": "BUNDLE_CONFLICT", "desc": "Bundle already exists"}`))
+				return
+			}
+			if strings.HasPrefix(uploaded, "invalid.tgz") {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"code": "INVALID_OBJECT_DEFINITION", "desc": "no config.yaml"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 2}`))
+		case "/api/v1/stack/bundle/":
+			// hash of bundle 3 is SHA-1 of "duplicate"
+			_, _ = w.Write([]byte(`[{"id": 2, "name": "good", "hash": "31047caa"}, {"id": 3, "name": "dup", "hash": "5e4cd5ffe4f23ec64d2656eaf161139052ed7739"}]`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithRetry(RetryConfig{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := c.UploadBundle(context.Background(), BundleSource{URL: server.URL + "/bundles/good.tgz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.ID != 2 || uploaded != "good.tgz:bundle" {
+		t.Errorf("Unexpected bundle %v uploaded as %s", bundle, uploaded)
+	}
+
+	for name, expected := range map[string]string{
+		"missing.tgz": "404 Not Found",
+		"page.tgz":    "text/html",
+		"empty.tgz":   "empty",
+		"broken.tgz":  "could not read bundle: unexpected EOF",
+		"invalid.tgz": "no config.yaml",
+	} {
+		uploaded = ""
+		_, err = c.UploadBundle(context.Background(), BundleSource{URL: server.URL + "/bundles/" + name})
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Unexpected error of %s: %v", name, err)
+		}
+	}
+
+	// load failure is reported with the uploaded file and the error of ADCM
+	_, err = c.UploadBundle(context.Background(), BundleSource{Content: []byte("invalid"), FileName: "invalid.tgz"})
+	expected := "could not load bundle invalid.tgz (uploaded archive is left in ADCM as its API can not remove it): " +
+		"POST /api/v1/stack/load/: status: 409, code: INVALID_OBJECT_DEFINITION, desc: no config.yaml"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Unexpected error of bundle load: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_OBJECT_DEFINITION" {
+		t.Errorf("Error of ADCM is not wrapped: %v", err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "local.tgz")
+	if err := os.WriteFile(bundlePath, []byte("local bundle"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		source   BundleSource
+		expected string
+	}{
+		{BundleSource{Path: bundlePath}, "local.tgz:local bundle"},
+		{BundleSource{URL: "file://" + bundlePath}, "local.tgz:local bundle"},
+		{BundleSource{Content: []byte("inline bundle"), FileName: "inline.tgz"}, "inline.tgz:inline bundle"},
+	} {
+		uploaded = ""
+		_, err = c.UploadBundle(context.Background(), tc.source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uploaded != tc.expected {
+			t.Errorf("Unexpected upload of %v: %s", tc.source, uploaded)
+		}
+	}
+	for _, source := range []BundleSource{{}, {Path: bundlePath, Content: []byte("bundle")}, {URL: "ftp://bundles/b.tgz"}} {
+		_, err = c.UploadBundle(context.Background(), source)
+		if err == nil {
+			t.Errorf("Invalid source is accepted: %v", source)
+		}
+	}
+
+	sum := sha256.Sum256([]byte("inline bundle"))
+	for checksum, valid := range map[string]bool{hex.EncodeToString(sum[:]): true, strings.ToUpper(hex.EncodeToString(sum[:])): true, "0000": false} {
+		uploaded = ""
+		_, err = c.UploadBundle(context.Background(), BundleSource{Content: []byte("inline bundle"), SHA256: checksum})
+		if valid && err != nil {
+			t.Errorf("Bundle with valid checksum %s is not uploaded: %s", checksum, err)
+		}
+		if !valid && (err == nil || !strings.Contains(err.Error(), "checksum mismatch") || uploaded != "") {
+			t.Errorf("Bundle with invalid checksum is uploaded: %v", err)
+		}
+	}
+	bundle, err = c.GetBundle(context.Background(), BundleSearch{Identifier: Identifier{ID: 2}})
+	if err != nil || bundle.Hash != "31047caa" {
+		t.Errorf("Unexpected bundle hash: %v %v", bundle, err)
+	}
+
+	dup := BundleSource{Content: []byte("duplicate"), FileName: "dup.tgz"}
+	_, err = c.UploadBundle(context.Background(), dup)
+	if err == nil || !strings.Contains(err.Error(), "BUNDLE_CONFLICT") {
+		t.Errorf("Unexpected error of duplicate bundle: %v", err)
+	}
+	dup.AdoptExisting = true
+	bundle, err = c.UploadBundle(context.Background(), dup)
+	if err != nil || bundle.ID != 3 || !bundle.Adopted {
+		t.Errorf("Duplicate bundle is not adopted: %v %v", bundle, err)
+	}
+	_, err = c.UploadBundle(context.Background(), BundleSource{Content: []byte("other"), FileName: "dup.tgz", AdoptExisting: true})
+	if err == nil {
+		t.Error("Bundle with other hash is adopted")
+	}
+}
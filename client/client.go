@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -18,8 +20,22 @@ type Client struct {
 	HTTPClient *http.Client
 	Token      string
 	Auth       AuthStruct
+	Retry      RetryConfig
+	TLSConfig  *tls.Config
+	Timeouts   Timeouts
+	TaskWait   TaskWaitConfig
+	Download   DownloadConfig
+
+	// downloadClient is used for bundle downloads which have own TLS settings
+	downloadClient *http.Client
+
+	// authMu guards Token which is renewed when ADCM rejects it
+	authMu sync.RWMutex
 }
 
+// Option - optional setting of client
+type Option func(c *Client)
+
 // AuthStruct -
 type AuthStruct struct {
 	Username string `json:"username"`
@@ -34,16 +50,38 @@ type AuthResponse struct {
 }
 
 // NewClient -
-func NewClient(url, username, password *string) (*Client, error) {
-	transport := http.DefaultTransport
-	// uncomment to disable proxy here as it may conflict with global one
-	// transport.(*http.Transport).Proxy = nil
+func NewClient(ctx context.Context, url, username, password *string, opts ...Option) (*Client, error) {
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second, Transport: transport},
+		// Requests are limited with per-request deadlines, see Timeouts
+		HTTPClient: &http.Client{},
 		// Default ADCM URL
-		HostURL: HostURL,
+		HostURL:  HostURL,
+		Retry:    DefaultRetryConfig,
+		Timeouts: DefaultTimeouts,
+		TaskWait: DefaultTaskWaitConfig,
+		Download: DefaultDownloadConfig,
 	}
 
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if err := c.Timeouts.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.TaskWait.validate(); err != nil {
+		return nil, err
+	}
+
+	// Dedicated transport is used to not affect other users of the global one
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// uncomment to disable proxy here as it may conflict with global one
+	// transport.Proxy = nil
+	if c.TLSConfig != nil {
+		transport.TLSClientConfig = c.TLSConfig
+	}
+	c.HTTPClient.Transport = transport
+	c.downloadClient = newDownloadClient(c.Download)
+
 	if url != nil {
 		c.HostURL = *url
 	}
@@ -58,7 +96,12 @@ func NewClient(url, username, password *string) (*Client, error) {
 		Password: *password,
 	}
 
-	ar, err := c.SignIn()
+	// Pre-issued token is used as is, credentials are kept to renew it
+	if c.Token != "" {
+		return &c, nil
+	}
+
+	ar, err := c.SignIn(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +112,7 @@ func NewClient(url, username, password *string) (*Client, error) {
 }
 
 func (c *Client) doRequest(req *http.Request, authToken *string) ([]byte, error) {
-	token := c.Token
+	token := c.currentToken()
 
 	if authToken != nil {
 		token = *authToken
@@ -77,20 +120,41 @@ func (c *Client) doRequest(req *http.Request, authToken *string) ([]byte, error)
 
 	req.Header.Set("Authorization", "Token "+token)
 
-	res, err := c.HTTPClient.Do(req)
+	res, body, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	// Token may be revoked or rotated during long apply, so sign in again and replay request once
+	if (res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden) &&
+		authToken == nil && c.Auth.Username != "" && rewindBody(req) == nil {
+		err = c.reauthenticate(req.Context(), token)
+		if err != nil {
+			return nil, fmt.Errorf("%w (re-authentication failed: %s)", newAPIError(req, res.StatusCode, body), err)
+		}
+		req.Header.Set("Authorization", "Token "+c.currentToken())
+		res, body, err = c.do(req)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusNoContent {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, newAPIError(req, res.StatusCode, body)
 	}
 
 	return body, err
 }
+
+// sleep pauses the current goroutine for at least the duration d
+// or until context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -2,25 +2,18 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
+	"net/url"
 
 	"github.com/imdario/mergo"
 )
 
-func (c *Client) getClusterPrototypeID(bundleID int64) (int64, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/stack/cluster/?bundle_id=%d", c.HostURL, bundleID), nil)
-	if err != nil {
-		return 0, err
-	}
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return 0, err
-	}
-	var clusterPrototypeIDS []Identifier
-	err = unwrapResults(body, &clusterPrototypeIDS)
+func (c *Client) getClusterPrototypeID(ctx context.Context, bundleID int64) (int64, error) {
+	clusterPrototypeIDS, err := getList[Identifier](ctx, c, fmt.Sprintf("%s/api/v1/stack/cluster/?bundle_id=%d", c.HostURL, bundleID))
 	if err != nil {
 		return 0, err
 	}
@@ -30,28 +23,34 @@ func (c *Client) getClusterPrototypeID(bundleID int64) (int64, error) {
 	return clusterPrototypeIDS[0].ID, nil
 }
 
-func (c *Client) getClusterActionID(clusterID int64, actionName string) (int64, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/cluster/%d/action/?name=%s", c.HostURL, clusterID, actionName), nil)
+func (c *Client) getServicePrototypeID(ctx context.Context, bundleID int64, serviceName string) (int64, error) {
+	servicePrototypeIDS, err := getList[Identifier](ctx, c, fmt.Sprintf("%s/api/v1/stack/service/?bundle_id=%d&name=%s", c.HostURL, bundleID, serviceName))
 	if err != nil {
 		return 0, err
 	}
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return 0, err
+	if len(servicePrototypeIDS) < 1 {
+		return 0, fmt.Errorf("no service prototypes found")
 	}
-	var actionIDs []Identifier
-	err = json.Unmarshal(body, &actionIDs)
+	return servicePrototypeIDS[0].ID, nil
+}
+
+func (c *Client) getServiceComponentID(ctx context.Context, clusterID, serviceID int64, componentName string) (int64, error) {
+	components, err := c.getServiceComponents(ctx, clusterID, serviceID)
 	if err != nil {
 		return 0, err
 	}
-	if len(actionIDs) < 1 {
-		return 0, fmt.Errorf("no action found")
+	for _, el := range components {
+		if el.Name == componentName {
+			return el.ID, nil
+		}
 	}
-	return actionIDs[0].ID, nil
+	return 0, fmt.Errorf("no service component id found")
 }
 
-func (c *Client) getClusterActionConfig(clusterID int64, actionID int64) (*ClusterConfigResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/cluster/%d/action/%d/", c.HostURL, clusterID, actionID), nil)
+func (c *Client) getServiceConfig(ctx context.Context, clusterID, serviceID int64) (*ServiceConfigResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/config/current/",
+			c.HostURL, clusterID, serviceID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -59,124 +58,58 @@ func (c *Client) getClusterActionConfig(clusterID int64, actionID int64) (*Clust
 	if err != nil {
 		return nil, err
 	}
-	var configSchema ClusterConfigResponse
-	err = json.Unmarshal(body, &configSchema)
+	var config ServiceConfigResponse
+	err = json.Unmarshal(body, &config)
 	if err != nil {
 		return nil, err
 	}
-	var config ClusterConfigResponse
-	config.Config = make(map[string]interface{})
-	if nestedConfig, defined := configSchema.Config["config"]; defined {
-		nestedConfigParsed, ok := nestedConfig.([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("unexpected config schema: not a list")
-		}
-		for _, elInt := range nestedConfigParsed {
-			el, ok := elInt.(map[string]interface{})
-			if !ok {
-				return nil, fmt.Errorf("unexpected config schema: not a map")
-			}
-			itemName, ok := el["name"]
-			if !ok {
-				return nil, fmt.Errorf("unexpected config schema: no name")
-			}
-			itemNameParsed, ok := itemName.(string)
-			if !ok {
-				return nil, fmt.Errorf("unexpected config schema: name not string")
-			}
-			if itemType, defined := el["type"]; defined {
-				if itemTypeParsed, ok := itemType.(string); ok {
-					if itemTypeParsed == "group" {
-						config.Config[itemNameParsed] = make(map[string]interface{})
-						continue
-					}
-				}
-			}
-			if itemSubName, defined := el["subname"]; defined && itemSubName != "" {
-				if itemSubNameParsed, ok := itemSubName.(string); ok {
-					if config.Config[itemNameParsed] == nil {
-						config.Config[itemNameParsed] = make(map[string]interface{})
-					}
-					config.Config[itemNameParsed].(map[string]interface{})[itemSubNameParsed] = el["value"]
-					continue
-				}
-			}
-			config.Config[itemNameParsed] = el["value"]
-		}
-	}
 	return &config, nil
 }
 
-func (c *Client) getServicePrototypeID(bundleID int64, serviceName string) (int64, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/stack/service/?bundle_id=%d&name=%s", c.HostURL, bundleID, serviceName), nil)
-	if err != nil {
-		return 0, err
-	}
-	body, err := c.doRequest(req, nil)
+func (c *Client) getClusterServices(ctx context.Context, clusterID int64) ([]Service, error) {
+	services, err := getList[Service](ctx, c, fmt.Sprintf("%s/api/v1/cluster/%d/service/", c.HostURL, clusterID))
 	if err != nil {
-		return 0, err
-	}
-	var servicePrototypeIDS []Identifier
-	err = unwrapResults(body, &servicePrototypeIDS)
-	if err != nil {
-		return 0, err
-	}
-	if len(servicePrototypeIDS) < 1 {
-		return 0, fmt.Errorf("no service prototypes found")
+		return nil, err
 	}
-	return servicePrototypeIDS[0].ID, nil
+	return services, nil
 }
 
-func (c *Client) getServiceComponentID(clusterID, serviceID int64, componentName string) (int64, error) {
-	req, err := http.NewRequest("GET",
-		fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/component/",
-			c.HostURL, clusterID, serviceID), nil)
+func (c *Client) updateServiceConfig(ctx context.Context, clusterID, serviceID int64, config, prior map[string]interface{}) error {
+	cfg, err := c.getServiceConfig(ctx, clusterID, serviceID)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return 0, err
+	delta := configDelta(cfg.Config, config, prior)
+	if len(delta) == 0 {
+		return nil
 	}
-	var components []Component
-	err = json.Unmarshal(body, &components)
-	if err != nil {
-		return 0, err
+	if cfg.Config == nil {
+		cfg.Config = make(map[string]interface{})
 	}
-	for _, el := range components {
-		if el.Name == componentName {
-			return el.ID, nil
-		}
-	}
-	return 0, fmt.Errorf("no service component id found")
-}
-
-func (c *Client) getServiceConfig(clusterID, serviceID int64) (*ServiceConfigResponse, error) {
-	req, err := http.NewRequest("GET",
-		fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/config/current/",
-			c.HostURL, clusterID, serviceID), nil)
+	err = mergo.Merge(&cfg.Config, delta, mergo.WithOverride)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	body, err := c.doRequest(req, nil)
+	data, err := json.Marshal(cfg)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var config ServiceConfigResponse
-	err = json.Unmarshal(body, &config)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/config/history/", c.HostURL, clusterID, serviceID), bytes.NewBuffer(data))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &config, nil
+	req.Header.Add("Content-Type", "application/json;charset=utf-8")
+	_, err = c.doRequest(req, nil)
+	return err
 }
 
 // CreateCluster - create cluster
-func (c *Client) CreateCluster(cluster Cluster) (*Cluster, error) {
-	clusterPrototypeID, err := c.getClusterPrototypeID(cluster.BundleID)
+func (c *Client) CreateCluster(ctx context.Context, cluster Cluster) (*Cluster, error) {
+	clusterPrototypeID, err := c.getClusterPrototypeID(ctx, cluster.BundleID)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/api/v1/stack/prototype/%d/accept_license/", c.HostURL, clusterPrototypeID), nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/api/v1/stack/prototype/%d/accept_license/", c.HostURL, clusterPrototypeID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +120,7 @@ func (c *Client) CreateCluster(cluster Cluster) (*Cluster, error) {
 
 	values := map[string]interface{}{"name": cluster.Name, "description": cluster.Description, "prototype_id": clusterPrototypeID}
 	jsonValue, _ := json.Marshal(values)
-	req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/", c.HostURL), bytes.NewBuffer(jsonValue))
+	req, err = http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/cluster/", c.HostURL), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
@@ -202,215 +135,190 @@ func (c *Client) CreateCluster(cluster Cluster) (*Cluster, error) {
 		return nil, err
 	}
 	if len(cluster.ClusterConfig.Config) > 0 {
-		createdCluster, err := c.GetCluster(ClusterSearch{Identifier: clusterID})
+		err = c.updateConfig(ctx, fmt.Sprintf("cluster/%d", clusterID.ID), cluster.ClusterConfig.Config, nil)
 		if err != nil {
 			return nil, err
 		}
-		err = mergo.Merge(&createdCluster.ClusterConfig.Config, cluster.ClusterConfig.Config, mergo.WithOverride)
+	}
+	if len(cluster.HCMap) > 0 {
+		err = c.applyHCMap(ctx, clusterID.ID, cluster.BundleID, cluster.HCMap, cluster.ServicesConfig.Config)
 		if err != nil {
 			return nil, err
 		}
-		cfgResponse := HostConfigResponse{createdCluster.ClusterConfig.Config}
-		data, err := json.Marshal(cfgResponse)
+	}
+
+	return c.GetCluster(ctx, ClusterSearch{Identifier: clusterID})
+}
+
+// GetClusters - list clusters matching search criteria, config of clusters is not fetched
+func (c *Client) GetClusters(ctx context.Context, searchOpts ClusterSearch) ([]Cluster, error) {
+	var clusterResponses []ClusterResponse
+	if searchOpts.ID != 0 {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/cluster/%d/", c.HostURL, searchOpts.ID), nil)
 		if err != nil {
 			return nil, err
 		}
-		req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/config/history/", c.HostURL, clusterID.ID), bytes.NewBuffer(data))
+		body, err := c.doRequest(req, nil)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Content-Type", "application/json;charset=utf-8")
-		_, err = c.doRequest(req, nil)
+		var clusterResponse ClusterResponse
+		err = json.Unmarshal(body, &clusterResponse)
 		if err != nil {
 			return nil, err
 		}
-	}
-	if len(cluster.HCMap) > 0 {
-		var hc []map[string]int64
-		addedServices := make(map[string]int64)
-		for hostFQDN, serviceList := range cluster.HCMap {
-			host, err := c.GetHost(HostSearch{FQDN: hostFQDN})
-			if err != nil {
-				return nil, err
-			}
-			jsonValue, _ = json.Marshal(map[string]interface{}{"host_id": host.ID, "description": ""})
-			req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/host/", c.HostURL, clusterID.ID), bytes.NewBuffer(jsonValue))
-			if err != nil {
-				return nil, err
-			}
-			req.Header.Add("Content-Type", "application/json;charset=utf-8")
-			body, err = c.doRequest(req, nil)
-			if err != nil {
-				return nil, err
-			}
-			for _, servicesMapConfig := range serviceList {
-				for serviceName, serviceComponents := range servicesMapConfig {
-					servicePrototypeID, err := c.getServicePrototypeID(cluster.BundleID, serviceName)
-					if err != nil {
-						return nil, err
-					}
-					if _, added := addedServices[serviceName]; !added {
-						values := map[string]interface{}{"cluster_id": clusterID.ID, "prototype_id": servicePrototypeID}
-						jsonValue, _ := json.Marshal(values)
-						req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/service/", c.HostURL, clusterID.ID), bytes.NewBuffer(jsonValue))
-						if err != nil {
-							return nil, err
-						}
-						req.Header.Add("Content-Type", "application/json;charset=utf-8")
-						body, err = c.doRequest(req, nil)
-						if err != nil {
-							return nil, err
-						}
-						time.Sleep(time.Second)
-						var serviceID Identifier
-						err = json.Unmarshal(body, &serviceID)
-						if err != nil {
-							return nil, err
-						}
-						if val, ok := cluster.ServicesConfig.Config[serviceName]; ok {
-							cfgReceived := val.(map[string]interface{})
-							cfg, err := c.getServiceConfig(clusterID.ID, serviceID.ID)
-							if err != nil {
-								return nil, err
-							}
-							err = mergo.Merge(&cfg.Config, cfgReceived, mergo.WithOverride)
-							if err != nil {
-								return nil, err
-							}
-							data, err := json.Marshal(cfg)
-							if err != nil {
-								return nil, err
-							}
-							req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/config/history/", c.HostURL, clusterID.ID, serviceID.ID), bytes.NewBuffer(data))
-							if err != nil {
-								return nil, err
-							}
-							req.Header.Add("Content-Type", "application/json;charset=utf-8")
-							_, err = c.doRequest(req, nil)
-							if err != nil {
-								return nil, err
-							}
-						}
-						addedServices[serviceName] = serviceID.ID
-					}
-					for _, componentName := range serviceComponents {
-						componentID, err := c.getServiceComponentID(clusterID.ID, addedServices[serviceName], componentName)
-						if err != nil {
-							return nil, err
-						}
-						hc = append(hc, map[string]int64{"component_id": componentID, "host_id": host.ID, "service_id": addedServices[serviceName]})
-					}
-				}
-			}
+		clusterResponses = append(clusterResponses, clusterResponse)
+	} else {
+		query := url.Values{}
+		if searchOpts.Name != "" {
+			query.Set("name", searchOpts.Name)
 		}
-		values := map[string]interface{}{"hc": hc}
-		jsonValue, _ := json.Marshal(values)
-		req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/hostcomponent/", c.HostURL, clusterID.ID), bytes.NewBuffer(jsonValue))
+		responses, err := getList[ClusterResponse](ctx, c, fmt.Sprintf("%s/api/v1/cluster/?%s", c.HostURL, query.Encode()))
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Content-Type", "application/json;charset=utf-8")
-		body, err = c.doRequest(req, nil)
-		if err != nil {
-			return nil, err
+		clusterResponses = responses
+	}
+
+	var clusters []Cluster
+	for _, h := range clusterResponses {
+		if searchOpts.Name != "" && searchOpts.Name != h.Name {
+			continue
+		}
+		if searchOpts.Description != "" && searchOpts.Description != h.Description {
+			continue
 		}
+		if searchOpts.BundleID != 0 && searchOpts.BundleID != h.BundleID {
+			continue
+		}
+		if searchOpts.ID != 0 && searchOpts.ID != h.ID {
+			continue
+		}
+		clusters = append(clusters, Cluster{ClusterResponse: h})
 	}
 
-	return c.GetCluster(ClusterSearch{Identifier: clusterID})
+	return clusters, nil
 }
 
-// GetClusters - list clusters
-func (c *Client) GetClusters() ([]Cluster, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/cluster/", c.HostURL), nil)
+// GetCluster - get cluster, config of cluster is not fetched
+func (c *Client) GetCluster(ctx context.Context, searchOpts ClusterSearch) (*Cluster, error) {
+	res, err := c.GetClusters(ctx, searchOpts)
 	if err != nil {
 		return nil, err
 	}
-
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return nil, err
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w. Please change your search criteria and try again", ErrNotFound)
+	}
+	if len(res) > 1 {
+		return nil, fmt.Errorf("%w. Please try a more specific search criteria", ErrAmbiguous)
 	}
-	var ids []Identifier
-	err = json.Unmarshal(body, &ids)
+	return &res[0], nil
+}
+
+// GetClusterConfig - get current config of cluster
+func (c *Client) GetClusterConfig(ctx context.Context, clusterID int64) (map[string]interface{}, error) {
+	return c.getCurrentConfig(ctx, fmt.Sprintf("cluster/%d", clusterID))
+}
+
+// UpdateCluster - update name, description, configs and host-component mapping of cluster
+func (c *Client) UpdateCluster(ctx context.Context, cluster Cluster) (*Cluster, error) {
+	h, err := c.GetCluster(ctx, ClusterSearch{Identifier: cluster.Identifier})
 	if err != nil {
 		return nil, err
 	}
-
-	var hosts []Cluster
-	for _, id := range ids {
-		req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/cluster/%d", c.HostURL, id.ID), nil)
+	if h.Name != cluster.Name || h.Description != cluster.Description {
+		values := map[string]interface{}{"name": cluster.Name, "description": cluster.Description}
+		jsonValue, _ := json.Marshal(values)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/v1/cluster/%d/", c.HostURL, h.ID), bytes.NewBuffer(jsonValue))
 		if err != nil {
 			return nil, err
 		}
-		body, err := c.doRequest(req, nil)
+		req.Header.Add("Content-Type", "application/json;charset=utf-8")
+		_, err = c.doRequest(req, nil)
 		if err != nil {
 			return nil, err
 		}
-		var clusterResponse ClusterResponse
-		err = json.Unmarshal(body, &clusterResponse)
+	}
+	if len(cluster.ClusterConfig.Config) > 0 {
+		err = c.updateConfig(ctx, fmt.Sprintf("cluster/%d", h.ID), cluster.ClusterConfig.Config, cluster.PriorClusterConfig)
 		if err != nil {
 			return nil, err
 		}
-		var host Cluster
-		host.ClusterResponse = clusterResponse
-		req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/cluster/%d/config/current/", c.HostURL, id.ID), nil)
+	}
+	if cluster.HCMap != nil {
+		current, err := c.GetHCMap(ctx, ClusterSearch{Identifier: h.Identifier})
 		if err != nil {
 			return nil, err
 		}
-		body, err = c.doRequest(req, nil)
+		if !HCMapEqual(current, cluster.HCMap) {
+			err = c.applyHCMap(ctx, h.ID, h.BundleID, cluster.HCMap, cluster.ServicesConfig.Config)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(cluster.ServicesConfig.Config) > 0 {
+		services, err := c.getClusterServices(ctx, h.ID)
 		if err != nil {
 			return nil, err
 		}
-		var clusterConfigResponse ClusterConfigResponse
-		err = json.Unmarshal(body, &clusterConfigResponse)
-		if err != nil {
-			return nil, fmt.Errorf("%s : %s", body, err)
+		for serviceName, val := range cluster.ServicesConfig.Config {
+			cfgReceived, ok := val.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("config of service %s is not an object", serviceName)
+			}
+			var serviceID int64
+			for _, service := range services {
+				if service.Name == serviceName {
+					serviceID = service.ID
+					break
+				}
+			}
+			if serviceID == 0 {
+				return nil, fmt.Errorf("service %s not found in cluster %d", serviceName, h.ID)
+			}
+			priorConfig, _ := cluster.PriorServicesConfig[serviceName].(map[string]interface{})
+			err = c.updateServiceConfig(ctx, h.ID, serviceID, cfgReceived, priorConfig)
+			if err != nil {
+				return nil, err
+			}
 		}
-		host.ClusterConfig = clusterConfigResponse
-		hosts = append(hosts, host)
 	}
 
-	return hosts, nil
+	return c.GetCluster(ctx, ClusterSearch{Identifier: h.Identifier})
 }
 
-// GetCluster - get cluster
-func (c *Client) GetCluster(searchOpts ClusterSearch) (*Cluster, error) {
-	hosts, err := c.GetClusters()
+// GetServicesConfig - get current config of every service in cluster keyed by service name
+func (c *Client) GetServicesConfig(ctx context.Context, cluster ClusterSearch) (map[string]interface{}, error) {
+	h, err := c.GetCluster(ctx, cluster)
 	if err != nil {
 		return nil, err
 	}
-	var res []Cluster
-	for _, h := range hosts {
-		if searchOpts.Name != "" && searchOpts.Name != h.Name {
-			continue
-		}
-		if searchOpts.Description != "" && searchOpts.Description != h.Description {
-			continue
-		}
-		if searchOpts.BundleID != 0 && searchOpts.BundleID != h.BundleID {
-			continue
-		}
-		if searchOpts.ID != 0 && searchOpts.ID != h.ID {
-			continue
-		}
-		res = append(res, h)
-	}
-	if len(res) == 0 {
-		return nil, fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+	services, err := c.getClusterServices(ctx, h.ID)
+	if err != nil {
+		return nil, err
 	}
-	if len(res) > 1 {
-		return nil, fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+	configs := make(map[string]interface{})
+	for _, service := range services {
+		cfg, err := c.getServiceConfig(ctx, h.ID, service.ID)
+		if err != nil {
+			return nil, err
+		}
+		configs[service.Name] = cfg.Config
 	}
-	return &res[0], nil
+	return configs, nil
 }
 
 // DeleteCluster - delete cluster
-func (c *Client) DeleteCluster(cluster ClusterSearch) error {
-	h, err := c.GetCluster(cluster)
+func (c *Client) DeleteCluster(ctx context.Context, cluster ClusterSearch) error {
+	h, err := c.GetCluster(ctx, cluster)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/cluster/%d/", c.HostURL, h.ID), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v1/cluster/%d/", c.HostURL, h.ID), nil)
 	if err != nil {
 		return err
 	}
@@ -421,58 +329,16 @@ func (c *Client) DeleteCluster(cluster ClusterSearch) error {
 	return nil
 }
 
-// ClusterAction - run action on cluster
-func (c *Client) ClusterAction(cluster ClusterSearch, actionName string, wait bool) error {
-	h, err := c.GetCluster(cluster)
+// ClusterAction - run action on cluster with config merged over the default values of action config
+func (c *Client) ClusterAction(ctx context.Context, cluster ClusterSearch, actionName string, config map[string]interface{}, wait bool) error {
+	h, err := c.GetCluster(ctx, cluster)
 	if err != nil {
 		return err
 	}
-	actionID, err := c.getClusterActionID(h.ID, actionName)
-	if err != nil {
+	if !wait {
+		_, err = c.startAction(ctx, fmt.Sprintf("cluster/%d", h.ID), actionName, config, nil, false)
 		return err
 	}
-	cfg, err := c.getClusterActionConfig(h.ID, actionID)
-	if err != nil {
-		return err
-	}
-	jsonValue, _ := json.Marshal(cfg)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/cluster/%d/action/%d/run/", c.HostURL, h.ID, actionID), bytes.NewBuffer(jsonValue))
-	if err != nil {
-		return err
-	}
-	req.Header.Add("Content-Type", "application/json;charset=utf-8")
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return err
-	}
-	var taskID Identifier
-	err = json.Unmarshal(body, &taskID)
-	if err != nil {
-		return err
-	}
-	if wait {
-		for i := 0; i < 100; i++ {
-			req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/task/%d", c.HostURL, taskID.ID), nil)
-			if err != nil {
-				return err
-			}
-			body, err := c.doRequest(req, nil)
-			if err != nil {
-				return err
-			}
-			var taskResponse TaskResponse
-			err = json.Unmarshal(body, &taskResponse)
-			if err != nil {
-				return err
-			}
-			if taskResponse.Status == "failed" {
-				return fmt.Errorf("failed task")
-			}
-			if taskResponse.Status != "running" {
-				return nil
-			}
-			time.Sleep(10 * time.Second)
-		}
-	}
-	return nil
+	_, err = c.RunAction(ctx, ActionObjectCluster, h.ID, actionName, config, nil, false)
+	return err
 }
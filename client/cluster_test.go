@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// updateServer serves GET requests with the bodies of responses keyed by path and records
+// other requests as "METHOD path" with decoded JSON bodies
+type updateServer struct {
+	*httptest.Server
+	responses map[string]string
+	requests  []string
+	bodies    map[string]map[string]interface{}
+}
+
+func newUpdateServer(t *testing.T, responses map[string]string) *updateServer {
+	s := &updateServer{responses: responses, bodies: make(map[string]map[string]interface{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			response, ok := s.responses[r.URL.Path]
+			if !ok {
+				t.Errorf("Unexpected request: %s %s", r.Method, r.URL.RequestURI())
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(response))
+			return
+		}
+		request := r.Method + " " + r.URL.Path
+		s.requests = append(s.requests, request)
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("Unexpected body of %s: %s", request, data)
+		}
+		s.bodies[request] = body
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	return s
+}
+
+func unmarshalJSON(t *testing.T, data string) map[string]interface{} {
+	var value map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestUpdateCluster(t *testing.T) {
+	server := newUpdateServer(t, map[string]string{
+		"/api/v1/cluster/1/":                          `{"id": 1, "name": "old", "description": "old", "bundle_id": 7}`,
+		"/api/v1/cluster/1/config/current/":           `{"config": {"port": 22, "repos": {"use_repo": false, "url": "http://repo"}}}`,
+		"/api/v1/cluster/1/service/":                  `[{"id": 2, "name": "hdfs"}, {"id": 3, "name": "yarn"}]`,
+		"/api/v1/cluster/1/service/2/config/current/": `{"config": {"port": 8020, "user": "hdfs"}, "attr": {}}`,
+		"/api/v1/cluster/1/service/3/config/current/": `{"config": {"port": 8032}, "attr": {}}`,
+	})
+	defer server.Close()
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := Cluster{
+		ClusterResponse: ClusterResponse{ClusterSearch{Identifier: Identifier{ID: 1}, Name: "new", Description: "new"}},
+		ClusterConfig:   ClusterConfigResponse{Config: unmarshalJSON(t, `{"port": 22, "repos": {"use_repo": true}}`)},
+		ServicesConfig:  ServiceConfigResponse{Config: unmarshalJSON(t, `{"hdfs": {"port": 9000}, "yarn": {"port": 8032}}`)},
+	}
+	_, err = c.UpdateCluster(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRequests := []string{
+		"PATCH /api/v1/cluster/1/",
+		"POST /api/v1/cluster/1/config/history/",
+		"POST /api/v1/cluster/1/service/2/config/history/",
+	}
+	if !reflect.DeepEqual(server.requests, expectedRequests) {
+		t.Fatalf("Unexpected requests: %v", server.requests)
+	}
+	if patch := server.bodies["PATCH /api/v1/cluster/1/"]; patch["name"] != "new" || patch["description"] != "new" {
+		t.Errorf("Unexpected cluster patch: %v", patch)
+	}
+	// only the changed leaf differs from the current config
+	expected := unmarshalJSON(t, `{"config": {"port": 22, "repos": {"use_repo": true, "url": "http://repo"}}}`)
+	if posted := server.bodies["POST /api/v1/cluster/1/config/history/"]; !reflect.DeepEqual(posted, expected) {
+		t.Errorf("Unexpected cluster config: %v", posted)
+	}
+	expected = unmarshalJSON(t, `{"config": {"port": 9000, "user": "hdfs"}, "attr": {}}`)
+	if posted := server.bodies["POST /api/v1/cluster/1/service/2/config/history/"]; !reflect.DeepEqual(posted, expected) {
+		t.Errorf("Unexpected service config: %v", posted)
+	}
+
+	// nothing is posted when name, description and configs are unchanged
+	server.requests = nil
+	server.responses["/api/v1/cluster/1/"] = `{"id": 1, "name": "new", "description": "new", "bundle_id": 7}`
+	cluster.ClusterConfig.Config = unmarshalJSON(t, `{"port": 22}`)
+	cluster.ServicesConfig.Config = unmarshalJSON(t, `{"hdfs": {"port": 8020}}`)
+	_, err = c.UpdateCluster(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("Unexpected requests for unchanged cluster: %v", server.requests)
+	}
+}
+
+func TestUpdateClusterHCMap(t *testing.T) {
+	server := newUpdateServer(t, map[string]string{
+		"/api/v1/cluster/1/":                     `{"id": 1, "name": "c", "bundle_id": 7}`,
+		"/api/v1/cluster/1/hostcomponent/":       `[{"id": 1, "host_id": 5, "service_id": 2, "component_id": 20}]`,
+		"/api/v1/cluster/1/host/":                `[{"id": 5, "fqdn": "h5"}]`,
+		"/api/v1/cluster/1/service/":             `[{"id": 2, "name": "hdfs"}]`,
+		"/api/v1/cluster/1/service/2/component/": `[{"id": 20, "name": "namenode"}]`,
+	})
+	defer server.Close()
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster := Cluster{ClusterResponse: ClusterResponse{ClusterSearch{Identifier: Identifier{ID: 1}, Name: "c"}}}
+
+	// mapping is not managed
+	_, err = c.UpdateCluster(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.requests) != 0 {
+		t.Fatalf("Unexpected requests for unmanaged mapping: %v", server.requests)
+	}
+
+	// mapping is unchanged
+	cluster.HCMap = map[string][]map[string][]string{"h5": {{"hdfs": {"namenode"}}}}
+	_, err = c.UpdateCluster(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.requests) != 0 {
+		t.Fatalf("Unexpected requests for unchanged mapping: %v", server.requests)
+	}
+
+	// mapping is cleared
+	cluster.HCMap = map[string][]map[string][]string{}
+	_, err = c.UpdateCluster(context.Background(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.requests, []string{"POST /api/v1/cluster/1/hostcomponent/"}) {
+		t.Fatalf("Unexpected requests for cleared mapping: %v", server.requests)
+	}
+	if hc, ok := server.bodies["POST /api/v1/cluster/1/hostcomponent/"]["hc"].([]interface{}); !ok || len(hc) != 0 {
+		t.Errorf("Unexpected mapping posted: %v", server.bodies["POST /api/v1/cluster/1/hostcomponent/"])
+	}
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/imdario/mergo"
+)
+
+// IsVaultEncrypted - reports whether config value is ansible vault ciphertext,
+// ADCM returns values of password and secrettext fields encrypted this way
+func IsVaultEncrypted(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, "$ANSIBLE_VAULT;")
+}
+
+// configDelta returns the part of desired config which differs from current one.
+// Nested groups are compared key by key, so only changed leaves are returned.
+// Encrypted secrets of current config never equal the plaintext, so they are
+// compared with prior config applied before instead, nil prior means it is unknown.
+func configDelta(current, desired, prior map[string]interface{}) map[string]interface{} {
+	delta := make(map[string]interface{})
+	for key, desiredValue := range desired {
+		currentValue, defined := current[key]
+		if !defined {
+			delta[key] = desiredValue
+			continue
+		}
+		desiredGroup, desiredIsGroup := desiredValue.(map[string]interface{})
+		currentGroup, currentIsGroup := currentValue.(map[string]interface{})
+		if desiredIsGroup && currentIsGroup {
+			priorGroup, _ := prior[key].(map[string]interface{})
+			if nested := configDelta(currentGroup, desiredGroup, priorGroup); len(nested) > 0 {
+				delta[key] = nested
+			}
+			continue
+		}
+		if IsVaultEncrypted(currentValue) && !IsVaultEncrypted(desiredValue) {
+			if priorValue, known := prior[key]; !known || !reflect.DeepEqual(priorValue, desiredValue) {
+				delta[key] = desiredValue
+			}
+			continue
+		}
+		if !reflect.DeepEqual(currentValue, desiredValue) {
+			delta[key] = desiredValue
+		}
+	}
+	return delta
+}
+
+func (c *Client) getCurrentConfig(ctx context.Context, objectPath string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/%s/config/current/", c.HostURL, objectPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	var config HostConfigResponse
+	err = json.Unmarshal(body, &config)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %s", body, err)
+	}
+	return config.Config, nil
+}
+
+// updateConfig posts new config history entry for object located at objectPath
+// (e.g. "host/1") if desired config differs from the current one, prior is the config applied before.
+func (c *Client) updateConfig(ctx context.Context, objectPath string, desired, prior map[string]interface{}) error {
+	current, err := c.getCurrentConfig(ctx, objectPath)
+	if err != nil {
+		return err
+	}
+	delta := configDelta(current, desired, prior)
+	if len(delta) == 0 {
+		return nil
+	}
+	if current == nil {
+		current = make(map[string]interface{})
+	}
+	err = mergo.Merge(&current, delta, mergo.WithOverride)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(HostConfigResponse{current})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/%s/config/history/", c.HostURL, objectPath), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json;charset=utf-8")
+	_, err = c.doRequest(req, nil)
+	return err
+}
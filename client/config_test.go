@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigDelta(t *testing.T) {
+	var current, desired map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+	"ansible_user": "adcm",
+	"ansible_port": 22,
+	"repos": {"use_repo": false, "url": "http://repo"},
+	"disable_firewall": true
+}`), &current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal([]byte(`{
+	"ansible_user": "adcm",
+	"ansible_port": 2222,
+	"repos": {"use_repo": true, "url": "http://repo"},
+	"new_key": "value"
+}`), &desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta := configDelta(current, desired, nil)
+	if len(delta) != 3 {
+		t.Fatalf("Unexpected delta size: %v", delta)
+	}
+	if _, ok := delta["ansible_user"]; ok {
+		t.Error("Unchanged key in delta")
+	}
+	repos, ok := delta["repos"].(map[string]interface{})
+	if !ok || len(repos) != 1 || repos["use_repo"] != true {
+		t.Errorf("Unexpected nested delta: %v", delta["repos"])
+	}
+	if len(configDelta(current, current, nil)) != 0 {
+		t.Error("Delta of equal configs is not empty")
+	}
+}
+
+func TestConfigDeltaSecrets(t *testing.T) {
+	current := map[string]interface{}{
+		"ansible_ssh_pass": "$ANSIBLE_VAULT;1.1;AES256\n6135",
+		"db":               map[string]interface{}{"password": "$ANSIBLE_VAULT;1.1;AES256\n3961"},
+	}
+	desired := map[string]interface{}{
+		"ansible_ssh_pass": "secret",
+		"db":               map[string]interface{}{"password": "db secret"},
+	}
+	if delta := configDelta(current, desired, desired); len(delta) != 0 {
+		t.Errorf("Unchanged secrets in delta: %v", delta)
+	}
+	prior := map[string]interface{}{
+		"ansible_ssh_pass": "old secret",
+		"db":               map[string]interface{}{"password": "db secret"},
+	}
+	delta := configDelta(current, desired, prior)
+	if len(delta) != 1 || delta["ansible_ssh_pass"] != "secret" {
+		t.Errorf("Unexpected delta of changed secret: %v", delta)
+	}
+	// secrets are applied if prior config is unknown
+	if delta := configDelta(current, desired, nil); len(delta) != 2 {
+		t.Errorf("Unexpected delta without prior config: %v", delta)
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// DownloadConfig - settings of bundle downloads from http(s) URLs
+type DownloadConfig struct {
+	// Mirror is base URL replacing scheme and host of bundle URLs if set,
+	// e.g. https://repo.example.com/bundles/b.tgz is downloaded from https://mirror.local/adcm/bundles/b.tgz
+	// with https://mirror.local/adcm mirror
+	Mirror string
+	// Retry is retry settings of transient download failures
+	Retry RetryConfig
+	// TLSConfig is TLS config of connections to bundle servers, system defaults are used if nil
+	TLSConfig *tls.Config
+}
+
+// DefaultDownloadConfig - download settings used if not overridden with WithDownload
+var DefaultDownloadConfig = DownloadConfig{
+	Retry: DefaultRetryConfig,
+}
+
+// WithDownload - set bundle download settings of client
+func WithDownload(download DownloadConfig) Option {
+	return func(c *Client) {
+		c.Download = download
+	}
+}
+
+// newDownloadClient creates HTTP client for bundle downloads
+func newDownloadClient(download DownloadConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if download.TLSConfig != nil {
+		transport.TLSClientConfig = download.TLSConfig
+	}
+	return &http.Client{Transport: transport}
+}
+
+// mirrorURL rewrites bundle URL to the mirror if it is configured
+func (c *Client) mirrorURL(bundleURL string) (string, error) {
+	if c.Download.Mirror == "" {
+		return bundleURL, nil
+	}
+	parsed, err := url.Parse(bundleURL)
+	if err != nil {
+		return "", err
+	}
+	mirror, err := url.Parse(strings.TrimSuffix(c.Download.Mirror, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid bundle mirror: %w", err)
+	}
+	parsed.Scheme = mirror.Scheme
+	parsed.Host = mirror.Host
+	parsed.User = mirror.User
+	parsed.Path = mirror.Path + parsed.Path
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
+
+// downloadRetryable reports whether download may be retried after the failure. Only timeouts,
+// refused or reset connections and 429 or 5xx responses are transient, other failures
+// (e.g. untrusted certificate or unknown host) are not fixed by retries.
+func downloadRetryable(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if res == nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+}
+
+// downloadBundleOnce starts download of bundle and checks that response looks like a bundle.
+// Response is returned for failures to decide on retry.
+func (c *Client) downloadBundleOnce(ctx context.Context, bundleURL string, source BundleSource) (io.ReadCloser, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", bundleURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range source.Headers {
+		req.Header.Set(name, value)
+	}
+	if source.Username != "" || source.Password != "" {
+		req.SetBasicAuth(source.Username, source.Password)
+	}
+	response, err := c.downloadClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		_ = response.Body.Close()
+		return nil, response, fmt.Errorf("download of %s failed with status %s", bundleURL, response.Status)
+	}
+	contentType := response.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "text/") || strings.HasPrefix(contentType, "application/json") {
+		_ = response.Body.Close()
+		return nil, response, fmt.Errorf("download of %s returned %s instead of bundle archive", bundleURL, contentType)
+	}
+	if response.ContentLength == 0 {
+		_ = response.Body.Close()
+		return nil, response, fmt.Errorf("download of %s returned empty bundle", bundleURL)
+	}
+	return response.Body, response, nil
+}
+
+// downloadBundle starts download of bundle from URL of source retrying transient failures
+func (c *Client) downloadBundle(ctx context.Context, source BundleSource) (io.ReadCloser, error) {
+	bundleURL, err := c.mirrorURL(source.URL)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		content, res, err := c.downloadBundleOnce(ctx, bundleURL, source)
+		if err == nil {
+			return content, nil
+		}
+		if attempt >= c.Download.Retry.MaxAttempts || !downloadRetryable(ctx, res, err) {
+			return nil, err
+		}
+		if sleepErr := sleep(ctx, c.Download.Retry.backoff(attempt, res)); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
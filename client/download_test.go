@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDownloadBundle(t *testing.T) {
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mirror/repo/b.tgz" {
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "secret" || r.Header.Get("X-JFrog-Art-Api") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write([]byte("bundle"))
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithDownload(DownloadConfig{
+		Mirror:    server.URL + "/mirror/",
+		Retry:     RetryConfig{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond},
+		TLSConfig: &tls.Config{RootCAs: pool},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := BundleSource{
+		URL:      "https://artifactory.example.com/repo/b.tgz",
+		Headers:  map[string]string{"X-JFrog-Art-Api": "key"},
+		Username: "user",
+		Password: "secret",
+	}
+	content, err := c.downloadBundle(context.Background(), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil || string(data) != "bundle" {
+		t.Errorf("Unexpected bundle content: %s %v", data, err)
+	}
+
+	// retries are exhausted
+	atomic.StoreInt32(&calls, -10)
+	_, err = c.downloadBundle(context.Background(), source)
+	if err == nil || atomic.LoadInt32(&calls) != -7 {
+		t.Errorf("Unexpected result of download after %d calls: %v", calls, err)
+	}
+
+	// server certificate is not trusted without download TLS config
+	c, err = NewClient(context.Background(), &server.URL, nil, nil, WithDownload(DownloadConfig{Retry: RetryConfig{MaxAttempts: 1}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.downloadBundle(context.Background(), BundleSource{URL: server.URL + "/mirror/repo/b.tgz"})
+	if err == nil {
+		t.Error("Bundle is downloaded from server with untrusted certificate")
+	}
+}
+
+func TestDownloadRetryable(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	reset := &url.Error{Op: "Get", URL: "https://repo/b.tgz", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}
+	for _, tc := range []struct {
+		name      string
+		res       *http.Response
+		err       error
+		retryable bool
+	}{
+		{"connection refused", nil, refused, true},
+		{"connection reset", nil, reset, true},
+		{"timeout", nil, &url.Error{Op: "Get", URL: "https://repo/b.tgz", Err: context.DeadlineExceeded}, true},
+		{"dns timeout", nil, &net.DNSError{Err: "timeout", Name: "repo", IsTimeout: true}, true},
+		{"unknown host", nil, &net.DNSError{Err: "no such host", Name: "repo", IsNotFound: true}, false},
+		{"untrusted certificate", nil, &url.Error{Op: "Get", URL: "https://repo/b.tgz", Err: x509.UnknownAuthorityError{}}, false},
+		{"unsupported scheme", nil, errors.New(`unsupported protocol scheme "ftp"`), false},
+		{"too many requests", &http.Response{StatusCode: http.StatusTooManyRequests}, errors.New("429"), true},
+		{"server error", &http.Response{StatusCode: http.StatusInternalServerError}, errors.New("500"), true},
+		{"not found", &http.Response{StatusCode: http.StatusNotFound}, errors.New("404"), false},
+	} {
+		if retryable := downloadRetryable(context.Background(), tc.res, tc.err); retryable != tc.retryable {
+			t.Errorf("Unexpected retryable of %s: %v", tc.name, retryable)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNotFound - object not found in ADCM
+	ErrNotFound = errors.New("your query returned no results")
+	// ErrAmbiguous - search criteria matched more than one object
+	ErrAmbiguous = errors.New("your query returned more than one result")
+)
+
+// APIError - error response of ADCM API
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Code and Desc are filled from the error body returned by ADCM
+	Code string `json:"code"`
+	Desc string `json:"desc"`
+	Body []byte
+}
+
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := APIError{
+		StatusCode: statusCode,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Body:       body,
+	}
+	// ADCM returns {"code": ..., "desc": ..., "level": ...} for handled errors
+	_ = json.Unmarshal(body, &apiErr)
+	return &apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s %s: status: %d, code: %s, desc: %s", e.Method, e.Path, e.StatusCode, e.Code, e.Desc)
+	}
+	return fmt.Sprintf("%s %s: status: %d, body: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// Is reports 404 responses as ErrNotFound
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/host/1/":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "HOST_NOT_FOUND", "level": "error", "desc": "host doesn't exist"}`))
+		case "/api/v1/host/":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<html>Server Error</html>`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("GET", server.URL+"/api/v1/host/1/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.doRequest(req, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Unexpected error type: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "HOST_NOT_FOUND" || apiErr.Path != "/api/v1/host/1/" {
+		t.Errorf("Unexpected error fields: %+v", apiErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("404 response is not reported as ErrNotFound")
+	}
+
+	req, err = http.NewRequest("GET", server.URL+"/api/v1/cluster/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.doRequest(req, nil)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("500 response is reported as ErrNotFound")
+	}
+
+	_, err = c.GetHost(context.Background(), HostSearch{FQDN: "h1"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Empty search result is not reported as ErrNotFound: %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// TaskEvent - change of ADCM task status received from event websocket
+type TaskEvent struct {
+	TaskID int64
+	Status string
+}
+
+// adcmEvent is a message of ADCM event websocket
+type adcmEvent struct {
+	Event  string `json:"event"`
+	Object struct {
+		Type    string `json:"type"`
+		ID      int64  `json:"id"`
+		Details struct {
+			Type  string      `json:"type"`
+			Value interface{} `json:"value"`
+		} `json:"details"`
+	} `json:"object"`
+}
+
+// subscribeTaskEvents connects to ADCM event websocket and streams changes of task statuses
+// until ctx is done or connection drops, the channel is closed then.
+func (c *Client) subscribeTaskEvents(ctx context.Context) (<-chan TaskEvent, error) {
+	wsURL := strings.TrimSuffix(c.HostURL, "/") + "/ws/event/"
+	switch {
+	case strings.HasPrefix(wsURL, "https://"):
+		wsURL = "wss://" + strings.TrimPrefix(wsURL, "https://")
+	case strings.HasPrefix(wsURL, "http://"):
+		wsURL = "ws://" + strings.TrimPrefix(wsURL, "http://")
+	default:
+		return nil, fmt.Errorf("unsupported scheme of ADCM URL %s", c.HostURL)
+	}
+	config, err := websocket.NewConfig(wsURL, c.HostURL)
+	if err != nil {
+		return nil, err
+	}
+	token := c.currentToken()
+	if token != "" {
+		config.Protocol = []string{"adcm", token}
+		config.Header.Set("Authorization", "Token "+token)
+	}
+	conn, err := c.dialEvents(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan TaskEvent)
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	go func() {
+		defer close(events)
+		for {
+			var event adcmEvent
+			if err := websocket.JSON.Receive(conn, &event); err != nil {
+				return
+			}
+			if event.Event != "change_job_status" || event.Object.Type != "task" {
+				continue
+			}
+			status, _ := event.Object.Details.Value.(string)
+			select {
+			case events <- TaskEvent{TaskID: event.Object.ID, Status: status}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// dialEvents connects to event websocket, through proxy of the client transport if any.
+// Unlike websocket.DialConfig it is aborted on ctx cancellation and limited with request timeout
+// including the websocket handshake, so a peer which never completes it does not block waiting.
+func (c *Client) dialEvents(ctx context.Context, config *websocket.Config) (*websocket.Conn, error) {
+	if c.Timeouts.Request > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeouts.Request)
+		defer cancel()
+	}
+	secure := config.Location.Scheme == "wss"
+	address := hostPort(config.Location, secure)
+	proxyURL, err := c.eventsProxy(config.Location)
+	if err != nil {
+		return nil, err
+	}
+	dialAddress := address
+	if proxyURL != nil {
+		dialAddress = hostPort(proxyURL, false)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", dialAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rest of handshake is limited with deadline of ctx and aborted on its cancellation
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	var mu sync.Mutex
+	finished, aborted := false, false
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			defer mu.Unlock()
+			if !finished {
+				aborted = true
+				_ = conn.Close()
+			}
+		case <-stop:
+		}
+	}()
+	ws, err := c.handshakeEvents(conn, config, address, proxyURL, secure)
+	mu.Lock()
+	finished = true
+	if aborted && err == nil {
+		err = ctx.Err()
+	}
+	mu.Unlock()
+	if err != nil {
+		_ = conn.Close()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			// deadline of connection is the one of ctx, which is about to be done
+			<-ctx.Done()
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+// hostPort returns host and port of URL with default port of its scheme
+func hostPort(u *url.URL, secure bool) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if secure {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+// eventsProxy returns proxy of the client transport for the websocket location. Only plain
+// http proxies are supported, subscription fails with https and socks proxies, so tasks are polled then.
+func (c *Client) eventsProxy(location *url.URL) (*url.URL, error) {
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		return nil, nil
+	}
+	// proxy is chosen the same way as for API requests to ADCM
+	requestURL := *location
+	requestURL.Scheme = "http"
+	if location.Scheme == "wss" {
+		requestURL.Scheme = "https"
+	}
+	proxyURL, err := transport.Proxy(&http.Request{URL: &requestURL})
+	if err != nil || proxyURL == nil {
+		return nil, err
+	}
+	if proxyURL.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported scheme of proxy %s for event websocket", proxyURL.Redacted())
+	}
+	return proxyURL, nil
+}
+
+// handshakeEvents opens tunnel through proxy if set, then makes TLS and websocket handshakes over conn
+func (c *Client) handshakeEvents(conn net.Conn, config *websocket.Config, address string, proxyURL *url.URL, secure bool) (*websocket.Conn, error) {
+	if proxyURL != nil {
+		req := &http.Request{Method: "CONNECT", URL: &url.URL{Opaque: address}, Host: address, Header: make(http.Header)}
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			req.SetBasicAuth(proxyURL.User.Username(), password)
+			req.Header["Proxy-Authorization"] = req.Header["Authorization"]
+			req.Header.Del("Authorization")
+		}
+		err := req.Write(conn)
+		if err != nil {
+			return nil, err
+		}
+		res, err := http.ReadResponse(bufio.NewReader(conn), req)
+		if err != nil {
+			return nil, err
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("proxy %s responded with %s", proxyURL.Redacted(), res.Status)
+		}
+	}
+	if !secure {
+		return websocket.NewClient(config, conn)
+	}
+	tlsConfig := &tls.Config{}
+	if c.TLSConfig != nil {
+		tlsConfig = c.TLSConfig.Clone()
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = config.Location.Hostname()
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	return websocket.NewClient(config, tlsConn)
+}
+
+// waitTaskChange waits for the next check of task status. Without events subscription
+// it sleeps for poll interval, otherwise it waits for event of the task limited with
+// events poll interval. Closed subscription falls back to polling, nil is returned for it then.
+func (c *Client) waitTaskChange(ctx context.Context, events <-chan TaskEvent, taskID int64) (<-chan TaskEvent, error) {
+	if events == nil {
+		return nil, sleep(ctx, c.TaskWait.PollInterval)
+	}
+	interval := c.TaskWait.EventsPollInterval
+	// logs are streamed on polling, so events only shorten waits then
+	if c.TaskWait.LogHandler != nil && c.TaskWait.PollInterval < interval {
+		interval = c.TaskWait.PollInterval
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return events, ctx.Err()
+		case <-timer.C:
+			return events, nil
+		case event, ok := <-events:
+			if !ok {
+				return nil, nil
+			}
+			if event.TaskID == taskID {
+				return events, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// eventServer serves task 1 which finishes after finish is closed and event websocket
+// which sends task events after finish is closed if drop is not set
+func eventServer(t *testing.T, finish chan struct{}, drop bool, polls *int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/ws/event/", websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			if r.Header.Get("Authorization") != "Token secret" {
+				t.Errorf("Unexpected authorization of event websocket: %s", r.Header.Get("Authorization"))
+			}
+			config.Protocol = []string{"adcm"}
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			if drop {
+				return
+			}
+			<-finish
+			_ = websocket.Message.Send(conn, `{"event": "change_job_status", "object": {"type": "job", "id": 10, "details": {"type": "status", "value": "success"}}}`)
+			_ = websocket.Message.Send(conn, `{"event": "change_job_status", "object": {"type": "task", "id": 2, "details": {"type": "status", "value": "success"}}}`)
+			_ = websocket.Message.Send(conn, `{"event": "change_job_status", "object": {"type": "task", "id": 1, "details": {"type": "status", "value": "success"}}}`)
+			// keep connection open until client closes it
+			var msg string
+			_ = websocket.Message.Receive(conn, &msg)
+		},
+	})
+	mux.HandleFunc("/api/v1/task/1/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(polls, 1)
+		select {
+		case <-finish:
+			_, _ = w.Write([]byte(`{"id": 1, "status": "success"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id": 1, "status": "running"}`))
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestWaitTaskEvents(t *testing.T) {
+	var polls int32
+	finish := make(chan struct{})
+	server := eventServer(t, finish, false, &polls)
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithToken("secret"), WithTaskWait(TaskWaitConfig{
+		PollInterval:       time.Hour,
+		EventsPollInterval: time.Hour,
+		Events:             true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { close(finish) })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	task, err := c.waitTask(ctx, 1, "Install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != TaskStatusSuccess || atomic.LoadInt32(&polls) != 2 {
+		t.Errorf("Unexpected task %v after %d polls", task, polls)
+	}
+}
+
+func TestWaitTaskEventsFallback(t *testing.T) {
+	var polls int32
+	finish := make(chan struct{})
+	server := eventServer(t, finish, true, &polls)
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithToken("secret"), WithTaskWait(TaskWaitConfig{
+		PollInterval:       time.Millisecond,
+		EventsPollInterval: time.Hour,
+		Events:             true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { close(finish) })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	task, err := c.waitTask(ctx, 1, "Install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != TaskStatusSuccess {
+		t.Errorf("Unexpected task %v", task)
+	}
+}
+
+func TestSubscribeTaskEventsHandshake(t *testing.T) {
+	// listener accepts connections but never completes websocket handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	hostURL := "http://" + listener.Addr().String()
+
+	c, err := NewClient(context.Background(), &hostURL, nil, nil,
+		WithTimeouts(Timeouts{Request: 100 * time.Millisecond, Upload: time.Minute, Task: time.Minute}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = c.subscribeTaskEvents(context.Background())
+	if err == nil || time.Since(start) > time.Second {
+		t.Errorf("Handshake is not limited with request timeout: %v after %s", err, time.Since(start))
+	}
+
+	// without request timeout handshake is aborted on cancellation
+	c.Timeouts.Request = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = c.subscribeTaskEvents(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+		t.Errorf("Handshake is not aborted on cancellation: %v after %s", err, time.Since(start))
+	}
+}
+
+func TestSubscribeTaskEventsProxy(t *testing.T) {
+	var polls int32
+	finish := make(chan struct{})
+	close(finish)
+	server := eventServer(t, finish, false, &polls)
+	defer server.Close()
+
+	var connects int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "CONNECT" || r.Host != strings.TrimPrefix(server.URL, "http://") {
+			t.Errorf("Unexpected proxy request: %s %s", r.Method, r.Host)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&connects, 1)
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer target.Close()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() { _, _ = io.Copy(target, conn) }()
+		_, _ = io.Copy(conn, target)
+	}))
+	defer proxy.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithToken("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyURL, _ := url.Parse(proxy.URL)
+	c.HTTPClient.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := c.subscribeTaskEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := <-events
+	if event.TaskID != 2 || atomic.LoadInt32(&connects) != 1 {
+		t.Errorf("Unexpected event %v through proxy", event)
+	}
+}
@@ -2,19 +2,21 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-
-	"github.com/imdario/mergo"
+	"net/url"
+	"strconv"
 )
 
 // CreateHost - create host
-func (c *Client) CreateHost(host Host) (*Host, error) {
+func (c *Client) CreateHost(ctx context.Context, host Host) (*Host, error) {
 	values := map[string]string{"fqdn": host.FQDN, "description": host.Description}
 
 	jsonValue, _ := json.Marshal(values)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/provider/%d/host/", c.HostURL, host.ProviderID), bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/provider/%d/host/", c.HostURL, host.ProviderID), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
@@ -28,58 +30,28 @@ func (c *Client) CreateHost(host Host) (*Host, error) {
 	if err != nil {
 		return nil, err
 	}
-	h, err := c.GetHost(HostSearch{Identifier: id})
-	if err != nil {
-		return nil, err
-	}
 	if len(host.Config) > 0 {
-		err := mergo.Merge(&h.Config, host.Config, mergo.WithOverride)
-		if err != nil {
-			return nil, err
-		}
-		cfgResponse := HostConfigResponse{h.Config}
-		data, err := json.Marshal(cfgResponse)
-		if err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/host/%d/config/history/", c.HostURL, id.ID), bytes.NewBuffer(data))
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Add("Content-Type", "application/json;charset=utf-8")
-		_, err = c.doRequest(req, nil)
+		err = c.updateConfig(ctx, fmt.Sprintf("host/%d", id.ID), host.Config, nil)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return c.GetHost(HostSearch{Identifier: id})
+	return c.GetHost(ctx, HostSearch{Identifier: id})
 }
 
-// GetHosts - list host
-func (c *Client) GetHosts() ([]Host, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/host/", c.HostURL), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	var ids []Identifier
-	err = json.Unmarshal(body, &ids)
-	if err != nil {
-		return nil, err
-	}
-
-	var hosts []Host
-	for _, id := range ids {
-		req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/host/%d", c.HostURL, id.ID), nil)
+// GetHosts - list hosts matching search criteria, config of hosts is not fetched
+func (c *Client) GetHosts(ctx context.Context, searchOpts HostSearch) ([]Host, error) {
+	var hostResponses []HostResponse
+	if searchOpts.ID != 0 {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/host/%d/", c.HostURL, searchOpts.ID), nil)
 		if err != nil {
 			return nil, err
 		}
 		body, err := c.doRequest(req, nil)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -88,36 +60,27 @@ func (c *Client) GetHosts() ([]Host, error) {
 		if err != nil {
 			return nil, err
 		}
-		var host Host
-		host.HostResponse = hostResponse
-		req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/host/%d/config/current/", c.HostURL, id.ID), nil)
-		if err != nil {
-			return nil, err
+		hostResponses = append(hostResponses, hostResponse)
+	} else {
+		query := url.Values{}
+		if searchOpts.FQDN != "" {
+			query.Set("fqdn", searchOpts.FQDN)
 		}
-		body, err = c.doRequest(req, nil)
-		if err != nil {
-			return nil, err
+		if searchOpts.ProviderID != 0 {
+			query.Set("provider_id", strconv.FormatInt(searchOpts.ProviderID, 10))
+		}
+		if searchOpts.ClusterID != 0 {
+			query.Set("cluster_id", strconv.FormatInt(searchOpts.ClusterID, 10))
 		}
-		var hostConfigResponse HostConfigResponse
-		err = json.Unmarshal(body, &hostConfigResponse)
+		responses, err := getList[HostResponse](ctx, c, fmt.Sprintf("%s/api/v1/host/?%s", c.HostURL, query.Encode()))
 		if err != nil {
-			return nil, fmt.Errorf("%s : %s", body, err)
+			return nil, err
 		}
-		host.HostConfigResponse = hostConfigResponse
-		hosts = append(hosts, host)
+		hostResponses = responses
 	}
 
-	return hosts, nil
-}
-
-// GetHost - get host
-func (c *Client) GetHost(searchOpts HostSearch) (*Host, error) {
-	hosts, err := c.GetHosts()
-	if err != nil {
-		return nil, err
-	}
-	var res []Host
-	for _, h := range hosts {
+	var hosts []Host
+	for _, h := range hostResponses {
 		if searchOpts.FQDN != "" && searchOpts.FQDN != h.FQDN {
 			continue
 		}
@@ -133,24 +96,69 @@ func (c *Client) GetHost(searchOpts HostSearch) (*Host, error) {
 		if searchOpts.ID != 0 && searchOpts.ID != h.ID {
 			continue
 		}
-		res = append(res, h)
+		hosts = append(hosts, Host{HostResponse: h})
+	}
+
+	return hosts, nil
+}
+
+// GetHost - get host, config of host is not fetched
+func (c *Client) GetHost(ctx context.Context, searchOpts HostSearch) (*Host, error) {
+	res, err := c.GetHosts(ctx, searchOpts)
+	if err != nil {
+		return nil, err
 	}
 	if len(res) == 0 {
-		return nil, fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+		return nil, fmt.Errorf("%w. Please change your search criteria and try again", ErrNotFound)
 	}
 	if len(res) > 1 {
-		return nil, fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+		return nil, fmt.Errorf("%w. Please try a more specific search criteria", ErrAmbiguous)
 	}
 	return &res[0], nil
 }
 
+// GetHostConfig - get current config of host
+func (c *Client) GetHostConfig(ctx context.Context, hostID int64) (map[string]interface{}, error) {
+	return c.getCurrentConfig(ctx, fmt.Sprintf("host/%d", hostID))
+}
+
+// UpdateHost - update description and config of host
+func (c *Client) UpdateHost(ctx context.Context, host Host) (*Host, error) {
+	h, err := c.GetHost(ctx, HostSearch{Identifier: host.Identifier})
+	if err != nil {
+		return nil, err
+	}
+	// ADCM rejects FQDN changes of hosts in use, so only description is changed in place
+	if h.Description != host.Description {
+		values := map[string]string{"description": host.Description}
+		jsonValue, _ := json.Marshal(values)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/v1/host/%d/", c.HostURL, h.ID), bytes.NewBuffer(jsonValue))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Content-Type", "application/json;charset=utf-8")
+		_, err = c.doRequest(req, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(host.Config) > 0 {
+		err = c.updateConfig(ctx, fmt.Sprintf("host/%d", h.ID), host.Config, host.PriorConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.GetHost(ctx, HostSearch{Identifier: h.Identifier})
+}
+
 // DeleteHost - create host
-func (c *Client) DeleteHost(host HostSearch) error {
-	h, err := c.GetHost(host)
+func (c *Client) DeleteHost(ctx context.Context, host HostSearch) error {
+	h, err := c.GetHost(ctx, host)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/host/%d/", c.HostURL, h.ID), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v1/host/%d/", c.HostURL, h.ID), nil)
 	if err != nil {
 		return err
 	}
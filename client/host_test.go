@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetHostLookup(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/api/v1/host/5/":
+			_, _ = w.Write([]byte(`{"id": 5, "fqdn": "h5", "provider_id": 1, "config": "http://127.0.0.1:8000/api/v1/host/5/config/"}`))
+		case "/api/v1/host/":
+			if r.URL.Query().Get("fqdn") != "h5" {
+				t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id": 5, "fqdn": "h5", "provider_id": 1}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host, err := c.GetHost(context.Background(), HostSearch{Identifier: Identifier{ID: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.FQDN != "h5" || len(requests) != 1 {
+		t.Errorf("Unexpected lookup by ID: %+v, requests: %v", host, requests)
+	}
+
+	requests = nil
+	host, err = c.GetHost(context.Background(), HostSearch{FQDN: "h5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.ID != 5 || len(requests) != 1 {
+		t.Errorf("Unexpected lookup by FQDN: %+v, requests: %v", host, requests)
+	}
+
+	_, err = c.GetHost(context.Background(), HostSearch{Identifier: Identifier{ID: 6}})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Unexpected error for missing host: %v", err)
+	}
+}
+
+func TestUpdateHost(t *testing.T) {
+	server := newUpdateServer(t, map[string]string{
+		"/api/v1/host/5/":                `{"id": 5, "fqdn": "h5", "description": "old", "provider_id": 1}`,
+		"/api/v1/host/5/config/current/": `{"config": {"ansible_user": "adcm", "ansible_port": 22}}`,
+	})
+	defer server.Close()
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := Host{
+		HostResponse:       HostResponse{HostSearch{Identifier: Identifier{ID: 5}, FQDN: "h5", Description: "new"}},
+		HostConfigResponse: HostConfigResponse{Config: unmarshalJSON(t, `{"ansible_user": "adcm"}`)},
+	}
+	_, err = c.UpdateHost(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.requests, []string{"PATCH /api/v1/host/5/"}) {
+		t.Fatalf("Unexpected requests for description change: %v", server.requests)
+	}
+	if patch := server.bodies["PATCH /api/v1/host/5/"]; patch["description"] != "new" || len(patch) != 1 {
+		t.Errorf("Unexpected host patch: %v", patch)
+	}
+
+	server.requests = nil
+	server.responses["/api/v1/host/5/"] = `{"id": 5, "fqdn": "h5", "description": "new", "provider_id": 1}`
+	host.Config = unmarshalJSON(t, `{"ansible_port": 2222}`)
+	_, err = c.UpdateHost(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.requests, []string{"POST /api/v1/host/5/config/history/"}) {
+		t.Fatalf("Unexpected requests for config change: %v", server.requests)
+	}
+	expected := unmarshalJSON(t, `{"config": {"ansible_user": "adcm", "ansible_port": 2222}}`)
+	if posted := server.bodies["POST /api/v1/host/5/config/history/"]; !reflect.DeepEqual(posted, expected) {
+		t.Errorf("Unexpected host config: %v", posted)
+	}
+}
+
+func TestUpdateHostSecrets(t *testing.T) {
+	server := newUpdateServer(t, map[string]string{
+		"/api/v1/host/5/":                `{"id": 5, "fqdn": "h5", "provider_id": 1}`,
+		"/api/v1/host/5/config/current/": `{"config": {"ansible_user": "adcm", "ansible_ssh_pass": "$ANSIBLE_VAULT;1.1;AES256\n6135"}}`,
+	})
+	defer server.Close()
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := Host{
+		HostResponse:       HostResponse{HostSearch{Identifier: Identifier{ID: 5}, FQDN: "h5"}},
+		HostConfigResponse: HostConfigResponse{Config: unmarshalJSON(t, `{"ansible_user": "adcm", "ansible_ssh_pass": "secret"}`)},
+		PriorConfig:        unmarshalJSON(t, `{"ansible_user": "adcm", "ansible_ssh_pass": "secret"}`),
+	}
+	_, err = c.UpdateHost(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.requests) != 0 {
+		t.Fatalf("Unexpected requests for unchanged secret: %v", server.requests)
+	}
+
+	host.Config = unmarshalJSON(t, `{"ansible_user": "adcm", "ansible_ssh_pass": "new secret"}`)
+	_, err = c.UpdateHost(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := unmarshalJSON(t, `{"config": {"ansible_user": "adcm", "ansible_ssh_pass": "new secret"}}`)
+	if posted := server.bodies["POST /api/v1/host/5/config/history/"]; !reflect.DeepEqual(posted, expected) {
+		t.Errorf("Unexpected host config with changed secret: %v", posted)
+	}
+}
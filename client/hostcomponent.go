@@ -0,0 +1,236 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type hcEntry struct {
+	host      string
+	service   string
+	component string
+}
+
+func hcMapEntries(hcMap map[string][]map[string][]string) map[hcEntry]bool {
+	entries := make(map[hcEntry]bool)
+	for hostFQDN, serviceList := range hcMap {
+		for _, servicesMapConfig := range serviceList {
+			for serviceName, serviceComponents := range servicesMapConfig {
+				for _, componentName := range serviceComponents {
+					entries[hcEntry{host: hostFQDN, service: serviceName, component: componentName}] = true
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// HCMapEqual - check if host-component mappings contain the same placement of components
+func HCMapEqual(a, b map[string][]map[string][]string) bool {
+	aEntries := hcMapEntries(a)
+	bEntries := hcMapEntries(b)
+	if len(aEntries) != len(bEntries) {
+		return false
+	}
+	for entry := range aEntries {
+		if !bEntries[entry] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Client) getClusterHosts(ctx context.Context, clusterID int64) ([]HostSearch, error) {
+	hosts, err := getList[HostSearch](ctx, c, fmt.Sprintf("%s/api/v1/cluster/%d/host/", c.HostURL, clusterID))
+	if err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
+func (c *Client) getServiceComponents(ctx context.Context, clusterID, serviceID int64) ([]Component, error) {
+	components, err := getList[Component](ctx, c, fmt.Sprintf("%s/api/v1/cluster/%d/service/%d/component/", c.HostURL, clusterID, serviceID))
+	if err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+func (c *Client) getHostComponents(ctx context.Context, clusterID int64) ([]HostComponent, error) {
+	hc, err := getList[HostComponent](ctx, c, fmt.Sprintf("%s/api/v1/cluster/%d/hostcomponent/", c.HostURL, clusterID))
+	if err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+// GetHCMap - get host-component mapping of cluster in {fqdn: [{service: [components]}]} form
+func (c *Client) GetHCMap(ctx context.Context, cluster ClusterSearch) (map[string][]map[string][]string, error) {
+	h, err := c.GetCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := c.getHostComponents(ctx, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := c.getClusterHosts(ctx, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	hostNames := make(map[int64]string)
+	for _, host := range hosts {
+		hostNames[host.ID] = host.FQDN
+	}
+	services, err := c.getClusterServices(ctx, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	serviceNames := make(map[int64]string)
+	componentNames := make(map[int64]string)
+	for _, service := range services {
+		serviceNames[service.ID] = service.Name
+		components, err := c.getServiceComponents(ctx, h.ID, service.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, component := range components {
+			componentNames[component.ID] = component.Name
+		}
+	}
+
+	placement := make(map[string]map[string][]string)
+	for _, el := range hc {
+		hostFQDN, ok := hostNames[el.HostID]
+		if !ok {
+			return nil, fmt.Errorf("host %d not found in cluster %d", el.HostID, h.ID)
+		}
+		serviceName, ok := serviceNames[el.ServiceID]
+		if !ok {
+			return nil, fmt.Errorf("service %d not found in cluster %d", el.ServiceID, h.ID)
+		}
+		componentName, ok := componentNames[el.ComponentID]
+		if !ok {
+			return nil, fmt.Errorf("component %d not found in service %s", el.ComponentID, serviceName)
+		}
+		if placement[hostFQDN] == nil {
+			placement[hostFQDN] = make(map[string][]string)
+		}
+		placement[hostFQDN][serviceName] = append(placement[hostFQDN][serviceName], componentName)
+	}
+	hcMap := make(map[string][]map[string][]string)
+	for hostFQDN, hostServices := range placement {
+		for _, components := range hostServices {
+			sort.Strings(components)
+		}
+		hcMap[hostFQDN] = []map[string][]string{hostServices}
+	}
+	return hcMap, nil
+}
+
+// applyHCMap adds missing hosts and services to the cluster and sets host-component mapping.
+// Config from servicesConfig is applied to the services added.
+func (c *Client) applyHCMap(ctx context.Context, clusterID, bundleID int64, hcMap map[string][]map[string][]string, servicesConfig map[string]interface{}) error {
+	hosts, err := c.getClusterHosts(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	clusterHosts := make(map[string]int64)
+	for _, host := range hosts {
+		clusterHosts[host.FQDN] = host.ID
+	}
+	services, err := c.getClusterServices(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	addedServices := make(map[string]int64)
+	for _, service := range services {
+		addedServices[service.Name] = service.ID
+	}
+
+	// empty list clears mapping, null is rejected by ADCM
+	hc := []map[string]int64{}
+	for hostFQDN, serviceList := range hcMap {
+		hostID, added := clusterHosts[hostFQDN]
+		if !added {
+			host, err := c.GetHost(ctx, HostSearch{FQDN: hostFQDN})
+			if err != nil {
+				return err
+			}
+			jsonValue, _ := json.Marshal(map[string]interface{}{"host_id": host.ID, "description": ""})
+			req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/cluster/%d/host/", c.HostURL, clusterID), bytes.NewBuffer(jsonValue))
+			if err != nil {
+				return err
+			}
+			req.Header.Add("Content-Type", "application/json;charset=utf-8")
+			_, err = c.doRequest(req, nil)
+			if err != nil {
+				return err
+			}
+			hostID = host.ID
+			clusterHosts[hostFQDN] = hostID
+		}
+		for _, servicesMapConfig := range serviceList {
+			for serviceName, serviceComponents := range servicesMapConfig {
+				if _, added := addedServices[serviceName]; !added {
+					servicePrototypeID, err := c.getServicePrototypeID(ctx, bundleID, serviceName)
+					if err != nil {
+						return err
+					}
+					values := map[string]interface{}{"cluster_id": clusterID, "prototype_id": servicePrototypeID}
+					jsonValue, _ := json.Marshal(values)
+					req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/cluster/%d/service/", c.HostURL, clusterID), bytes.NewBuffer(jsonValue))
+					if err != nil {
+						return err
+					}
+					req.Header.Add("Content-Type", "application/json;charset=utf-8")
+					body, err := c.doRequest(req, nil)
+					if err != nil {
+						return err
+					}
+					err = sleep(ctx, time.Second)
+					if err != nil {
+						return err
+					}
+					var serviceID Identifier
+					err = json.Unmarshal(body, &serviceID)
+					if err != nil {
+						return err
+					}
+					if val, ok := servicesConfig[serviceName]; ok {
+						cfgReceived, ok := val.(map[string]interface{})
+						if !ok {
+							return fmt.Errorf("config of service %s is not an object", serviceName)
+						}
+						err = c.updateServiceConfig(ctx, clusterID, serviceID.ID, cfgReceived, nil)
+						if err != nil {
+							return err
+						}
+					}
+					addedServices[serviceName] = serviceID.ID
+				}
+				for _, componentName := range serviceComponents {
+					componentID, err := c.getServiceComponentID(ctx, clusterID, addedServices[serviceName], componentName)
+					if err != nil {
+						return err
+					}
+					hc = append(hc, map[string]int64{"component_id": componentID, "host_id": hostID, "service_id": addedServices[serviceName]})
+				}
+			}
+		}
+	}
+	values := map[string]interface{}{"hc": hc}
+	jsonValue, _ := json.Marshal(values)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/cluster/%d/hostcomponent/", c.HostURL, clusterID), bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json;charset=utf-8")
+	_, err = c.doRequest(req, nil)
+	return err
+}
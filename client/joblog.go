@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// JobLogLine - line of ADCM job log streamed while waiting for task
+type JobLogLine struct {
+	TaskID int64
+	JobID  int64
+	// Job is display name of the job
+	Job string
+	// Action is name of the action which created the task
+	Action string
+	// Log is name of the log, e.g. ansible
+	Log string
+	// Type is type of the log, stdout or stderr
+	Type string
+	Line string
+}
+
+// JobLogHandler - handler of job log lines, called from the goroutine waiting for task
+type JobLogHandler func(ctx context.Context, line JobLogLine)
+
+// jobLogTailer emits new lines of job logs of task on every call of tail
+type jobLogTailer struct {
+	c      *Client
+	action string
+	// seen holds number of lines already emitted per job and log
+	seen map[[2]int64]int
+	// done holds jobs finished and streamed completely
+	done map[int64]bool
+}
+
+func (c *Client) newJobLogTailer(action string) *jobLogTailer {
+	return &jobLogTailer{c: c, action: action, seen: make(map[[2]int64]int), done: make(map[int64]bool)}
+}
+
+// tail emits lines of stdout and stderr logs of started jobs of task which were not emitted before.
+// Logs are streamed on best effort basis, so errors only stop streaming until the next call.
+func (t *jobLogTailer) tail(ctx context.Context, task *TaskResponse) {
+	if t.c.TaskWait.LogHandler == nil {
+		return
+	}
+	for _, job := range task.Jobs {
+		if job.Status == TaskStatusCreated || t.done[job.ID] {
+			continue
+		}
+		logs, err := getList[JobLog](ctx, t.c, fmt.Sprintf("%s/api/v1/job/%d/log/", t.c.HostURL, job.ID))
+		if err != nil {
+			return
+		}
+		for _, log := range logs {
+			if log.Type != "stdout" && log.Type != "stderr" {
+				continue
+			}
+			content, err := t.c.getJobLog(ctx, job.ID, log.ID)
+			if err != nil {
+				return
+			}
+			trimmed := strings.TrimRight(content, "\n")
+			if trimmed == "" {
+				continue
+			}
+			lines := strings.Split(trimmed, "\n")
+			key := [2]int64{job.ID, log.ID}
+			// last line of running job may be incomplete yet
+			complete := len(lines)
+			if !taskFinished(job.Status) && !strings.HasSuffix(content, "\n") {
+				complete--
+			}
+			if t.seen[key] >= complete {
+				continue
+			}
+			for _, line := range lines[t.seen[key]:complete] {
+				t.c.TaskWait.LogHandler(ctx, JobLogLine{
+					TaskID: task.ID,
+					JobID:  job.ID,
+					Job:    job.DisplayName,
+					Action: t.action,
+					Log:    log.Name,
+					Type:   log.Type,
+					Line:   line,
+				})
+			}
+			t.seen[key] = complete
+		}
+		t.done[job.ID] = taskFinished(job.Status)
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobLogStreaming(t *testing.T) {
+	var calls int32
+	stdout := []string{
+		`"line 1\nline 2\nline"`,
+		`"line 1\nline 2\nline 3\n"`,
+		`"line 1\nline 2\nline 3\nline 4\n"`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.LoadInt32(&calls))
+		switch r.URL.Path {
+		case "/api/v1/task/1/":
+			call = int(atomic.AddInt32(&calls, 1))
+			if call < len(stdout) {
+				_, _ = w.Write([]byte(`{"id": 1, "status": "running", "jobs": [{"id": 10, "display_name": "Install", "status": "running"}, {"id": 11, "status": "created"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 1, "status": "success", "jobs": [{"id": 10, "display_name": "Install", "status": "success"}, {"id": 11, "status": "success"}]}`))
+		case "/api/v1/job/10/log/":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "ansible", "type": "stdout"}, {"id": 2, "name": "ansible", "type": "check"}]`))
+		case "/api/v1/job/10/log/1/":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "ansible", "type": "stdout", "content": ` + stdout[call-1] + `}`))
+		case "/api/v1/job/11/log/":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var lines []string
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, WithTaskWait(TaskWaitConfig{
+		PollInterval: time.Millisecond,
+		LogHandler: func(ctx context.Context, line JobLogLine) {
+			if line.TaskID != 1 || line.JobID != 10 || line.Job != "Install" || line.Action != "Install" || line.Type != "stdout" {
+				t.Errorf("Unexpected log line: %v", line)
+			}
+			lines = append(lines, line.Line)
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.waitTask(context.Background(), 1, "Install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lines, []string{"line 1", "line 2", "line 3", "line 4"}) {
+		t.Errorf("Unexpected log lines: %q", lines)
+	}
+}
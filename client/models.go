@@ -1,11 +1,16 @@
 package client
 
+import "encoding/json"
+
 type Identifier struct {
 	ID int64 `json:"id"`
 }
 
 type Bundle struct {
 	BundleSearch
+	Hash string `json:"hash"`
+	// Adopted is set if bundle was loaded to ADCM before and adopted by UploadBundle
+	Adopted bool `json:"-"`
 }
 
 type BundleSearch struct {
@@ -21,6 +26,8 @@ type BundleSearch struct {
 type Provider struct {
 	ProviderSearch
 	ProviderConfig ProviderConfigResponse
+	// PriorConfig is config applied before, it is used on update to detect changes of encrypted secrets
+	PriorConfig map[string]interface{} `json:"-"`
 }
 
 type ProviderSearch struct {
@@ -37,6 +44,8 @@ type ProviderConfigResponse struct {
 type Host struct {
 	HostResponse
 	HostConfigResponse
+	// PriorConfig is config applied before, it is used on update to detect changes of encrypted secrets
+	PriorConfig map[string]interface{} `json:"-"`
 }
 
 type HostResponse struct {
@@ -59,8 +68,14 @@ type Cluster struct {
 	ClusterResponse
 	ServicesConfig ServiceConfigResponse
 	ClusterConfig  ClusterConfigResponse
-	HCMap          map[string][]map[string][]string `json:"hc_map"`
-	State          string                           `json:"state"`
+	// HCMap is host-component mapping of cluster, nil HCMap leaves mapping untouched on update
+	// while empty one removes all components from hosts
+	HCMap map[string][]map[string][]string `json:"hc_map"`
+	State string                           `json:"state"`
+	// PriorClusterConfig and PriorServicesConfig are configs applied before,
+	// they are used on update to detect changes of encrypted secrets
+	PriorClusterConfig  map[string]interface{} `json:"-"`
+	PriorServicesConfig map[string]interface{} `json:"-"`
 }
 
 type ClusterResponse struct {
@@ -83,12 +98,41 @@ type ClusterSearch struct {
 	BundleID    int64  `json:"bundle_id"`
 }
 
+type Service struct {
+	Identifier
+	Name        string `json:"name"`
+	ClusterID   int64  `json:"cluster_id"`
+	PrototypeID int64  `json:"prototype_id"`
+}
+
 type Component struct {
 	Identifier
 	Name string `json:"name"`
 }
 
+type HostComponent struct {
+	Identifier
+	HostID      int64 `json:"host_id"`
+	ServiceID   int64 `json:"service_id"`
+	ComponentID int64 `json:"component_id"`
+}
+
 type TaskResponse struct {
 	Identifier
 	Status string `json:"status"`
+	Jobs   []Job  `json:"jobs"`
+}
+
+type Job struct {
+	Identifier
+	DisplayName string `json:"display_name"`
+	Status      string `json:"status"`
+}
+
+type JobLog struct {
+	Identifier
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Format  string          `json:"format"`
+	Content json.RawMessage `json:"content"`
 }
@@ -2,23 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/imdario/mergo"
 	"net/http"
+	"net/url"
 )
 
-func (c *Client) getProviderPrototypeID(bundleID int64) (int64, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/stack/provider/?bundle_id=%d", c.HostURL, bundleID), nil)
-	if err != nil {
-		return 0, err
-	}
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return 0, err
-	}
-	var clusterPrototypeIDS []Identifier
-	err = unwrapResults(body, &clusterPrototypeIDS)
+func (c *Client) getProviderPrototypeID(ctx context.Context, bundleID int64) (int64, error) {
+	clusterPrototypeIDS, err := getList[Identifier](ctx, c, fmt.Sprintf("%s/api/v1/stack/provider/?bundle_id=%d", c.HostURL, bundleID))
 	if err != nil {
 		return 0, err
 	}
@@ -28,53 +21,41 @@ func (c *Client) getProviderPrototypeID(bundleID int64) (int64, error) {
 	return clusterPrototypeIDS[0].ID, nil
 }
 
-// GetProviders - Returns list of providers
-func (c *Client) GetProviders() ([]Provider, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/provider", c.HostURL), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var ids []Identifier
-	err = json.Unmarshal(body, &ids)
-	if err != nil {
-		return nil, err
-	}
-
-	var providers []Provider
-	for _, id := range ids {
-		req, err = http.NewRequest("GET", fmt.Sprintf("%s/api/v1/provider/%d", c.HostURL, id.ID), nil)
+// GetProviders - Returns list of providers matching search criteria, config of providers is not fetched
+func (c *Client) GetProviders(ctx context.Context, searchOpts ProviderSearch) ([]Provider, error) {
+	var providerResponses []ProviderSearch
+	if searchOpts.ID != 0 {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/provider/%d/", c.HostURL, searchOpts.ID), nil)
 		if err != nil {
 			return nil, err
 		}
 		body, err := c.doRequest(req, nil)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
 		if err != nil {
 			return nil, err
 		}
-		var provider Provider
-		err = json.Unmarshal(body, &provider)
+		var providerResponse ProviderSearch
+		err = json.Unmarshal(body, &providerResponse)
 		if err != nil {
 			return nil, err
 		}
-		providers = append(providers, provider)
+		providerResponses = append(providerResponses, providerResponse)
+	} else {
+		query := url.Values{}
+		if searchOpts.Name != "" {
+			query.Set("name", searchOpts.Name)
+		}
+		responses, err := getList[ProviderSearch](ctx, c, fmt.Sprintf("%s/api/v1/provider/?%s", c.HostURL, query.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		providerResponses = responses
 	}
 
-	return providers, nil
-}
-
-// GetProvider - Returns provider
-func (c *Client) GetProvider(searchOpts ProviderSearch) (*Provider, error) {
-	bundles, err := c.GetProviders()
-	if err != nil {
-		return nil, err
-	}
-	var res []Provider
-	for _, b := range bundles {
+	var providers []Provider
+	for _, b := range providerResponses {
 		if searchOpts.Name != "" && searchOpts.Name != b.Name {
 			continue
 		}
@@ -90,27 +71,42 @@ func (c *Client) GetProvider(searchOpts ProviderSearch) (*Provider, error) {
 		if searchOpts.ID != 0 && searchOpts.ID != b.ID {
 			continue
 		}
-		res = append(res, b)
+		providers = append(providers, Provider{ProviderSearch: b})
+	}
+
+	return providers, nil
+}
+
+// GetProvider - Returns provider, config of provider is not fetched
+func (c *Client) GetProvider(ctx context.Context, searchOpts ProviderSearch) (*Provider, error) {
+	res, err := c.GetProviders(ctx, searchOpts)
+	if err != nil {
+		return nil, err
 	}
 	if len(res) == 0 {
-		return nil, fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+		return nil, fmt.Errorf("%w. Please change your search criteria and try again", ErrNotFound)
 	}
 	if len(res) > 1 {
-		return nil, fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+		return nil, fmt.Errorf("%w. Please try a more specific search criteria", ErrAmbiguous)
 	}
 	return &res[0], nil
 }
 
+// GetProviderConfig - get current config of provider
+func (c *Client) GetProviderConfig(ctx context.Context, providerID int64) (map[string]interface{}, error) {
+	return c.getCurrentConfig(ctx, fmt.Sprintf("provider/%d", providerID))
+}
+
 // CreateProvider - create provider
-func (c *Client) CreateProvider(provider Provider) (*Provider, error) {
-	providerPrototypeID, err := c.getProviderPrototypeID(provider.BundleID)
+func (c *Client) CreateProvider(ctx context.Context, provider Provider) (*Provider, error) {
+	providerPrototypeID, err := c.getProviderPrototypeID(ctx, provider.BundleID)
 	if err != nil {
 		return nil, err
 	}
 
 	values := map[string]interface{}{"name": provider.Name, "description": provider.Description, "prototype_id": providerPrototypeID}
 	jsonValue, _ := json.Marshal(values)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/provider/", c.HostURL), bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/provider/", c.HostURL), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
@@ -125,40 +121,51 @@ func (c *Client) CreateProvider(provider Provider) (*Provider, error) {
 		return nil, err
 	}
 	if len(provider.ProviderConfig.Config) > 0 {
-		createdProvider, err := c.GetProvider(ProviderSearch{Identifier: clusterID})
+		err = c.updateConfig(ctx, fmt.Sprintf("provider/%d", clusterID.ID), provider.ProviderConfig.Config, nil)
 		if err != nil {
 			return nil, err
 		}
-		err = mergo.Merge(&createdProvider.ProviderConfig.Config, provider.ProviderConfig.Config, mergo.WithOverride)
-		if err != nil {
-			return nil, err
-		}
-		cfgResponse := ProviderConfigResponse{createdProvider.ProviderConfig.Config}
-		data, err := json.Marshal(cfgResponse)
+	}
+
+	return c.GetProvider(ctx, ProviderSearch{Identifier: clusterID})
+}
+
+// UpdateProvider - update name, description and config of provider
+func (c *Client) UpdateProvider(ctx context.Context, provider Provider) (*Provider, error) {
+	p, err := c.GetProvider(ctx, ProviderSearch{Identifier: provider.Identifier})
+	if err != nil {
+		return nil, err
+	}
+	if p.Name != provider.Name || p.Description != provider.Description {
+		values := map[string]interface{}{"name": provider.Name, "description": provider.Description}
+		jsonValue, _ := json.Marshal(values)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/v1/provider/%d/", c.HostURL, p.ID), bytes.NewBuffer(jsonValue))
 		if err != nil {
 			return nil, err
 		}
-		req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/provider/%d/config/history/", c.HostURL, clusterID.ID), bytes.NewBuffer(data))
+		req.Header.Add("Content-Type", "application/json;charset=utf-8")
+		_, err = c.doRequest(req, nil)
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Content-Type", "application/json;charset=utf-8")
-		_, err = c.doRequest(req, nil)
+	}
+	if len(provider.ProviderConfig.Config) > 0 {
+		err = c.updateConfig(ctx, fmt.Sprintf("provider/%d", p.ID), provider.ProviderConfig.Config, provider.PriorConfig)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return c.GetProvider(ProviderSearch{Identifier: clusterID})
+	return c.GetProvider(ctx, ProviderSearch{Identifier: p.Identifier})
 }
 
 // DeleteProvider - create host
-func (c *Client) DeleteProvider(provider ProviderSearch) error {
-	h, err := c.GetProvider(provider)
+func (c *Client) DeleteProvider(ctx context.Context, provider ProviderSearch) error {
+	h, err := c.GetProvider(ctx, provider)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/provider/%d/", c.HostURL, h.ID), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v1/provider/%d/", c.HostURL, h.ID), nil)
 	if err != nil {
 		return err
 	}
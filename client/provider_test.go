@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateProvider(t *testing.T) {
+	server := newUpdateServer(t, map[string]string{
+		"/api/v1/provider/1/":                `{"id": 1, "name": "ssh", "description": "old", "bundle_id": 3}`,
+		"/api/v1/provider/1/config/current/": `{"config": {"ansible_user": "adcm", "ansible_port": 22}}`,
+	})
+	defer server.Close()
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := Provider{
+		ProviderSearch: ProviderSearch{Identifier: Identifier{ID: 1}, Name: "ssh", Description: "new"},
+		ProviderConfig: ProviderConfigResponse{Config: unmarshalJSON(t, `{"ansible_port": 22}`)},
+	}
+	_, err = c.UpdateProvider(context.Background(), provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.requests, []string{"PATCH /api/v1/provider/1/"}) {
+		t.Fatalf("Unexpected requests for description change: %v", server.requests)
+	}
+	if patch := server.bodies["PATCH /api/v1/provider/1/"]; patch["description"] != "new" || patch["name"] != "ssh" {
+		t.Errorf("Unexpected provider patch: %v", patch)
+	}
+
+	server.requests = nil
+	server.responses["/api/v1/provider/1/"] = `{"id": 1, "name": "ssh", "description": "new", "bundle_id": 3}`
+	provider.ProviderConfig.Config = unmarshalJSON(t, `{"ansible_user": "root"}`)
+	_, err = c.UpdateProvider(context.Background(), provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.requests, []string{"POST /api/v1/provider/1/config/history/"}) {
+		t.Fatalf("Unexpected requests for config change: %v", server.requests)
+	}
+	expected := unmarshalJSON(t, `{"config": {"ansible_user": "root", "ansible_port": 22}}`)
+	if posted := server.bodies["POST /api/v1/provider/1/config/history/"]; !reflect.DeepEqual(posted, expected) {
+		t.Errorf("Unexpected provider config: %v", posted)
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig - retry settings for transient ADCM failures
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, 1 disables retries
+	MaxAttempts int
+	// WaitMin is the backoff before the second attempt, doubled on every next one
+	WaitMin time.Duration
+	// WaitMax caps the backoff
+	WaitMax time.Duration
+}
+
+// DefaultRetryConfig - retry settings used if not overridden with WithRetry
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 4,
+	WaitMin:     time.Second,
+	WaitMax:     30 * time.Second,
+}
+
+// WithRetry - set retry settings of client
+func WithRetry(retry RetryConfig) Option {
+	return func(c *Client) {
+		c.Retry = retry
+	}
+}
+
+// do sends request and reads response body retrying transient failures.
+// Response body is already closed when returned.
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		res, body, err := c.send(req)
+		if attempt >= c.Retry.MaxAttempts || !retryable(req, res, body, err) {
+			return res, body, err
+		}
+		if rewindBody(req) != nil {
+			return res, body, err
+		}
+		if sleepErr := sleep(req.Context(), c.Retry.backoff(attempt, res)); sleepErr != nil {
+			return res, body, err
+		}
+	}
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if timeout := c.requestTimeout(req.Context()); timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, body, nil
+}
+
+// lockErrorCodes are codes of ADCM errors returned with 409 while object is locked by running task,
+// other conflicts (e.g. duplicate names) are permanent
+var lockErrorCodes = map[string]bool{
+	"LOCK_ERROR": true,
+}
+
+// retryable reports whether request may be replayed after the failure.
+// Idempotent requests are replayed on any transient failure, while other ones
+// only if ADCM surely did not process them: connection was not established,
+// object was locked by running task or service was not available.
+func retryable(req *http.Request, res *http.Response, body []byte, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	idempotent := req.Method == "GET" || req.Method == "HEAD" || req.Method == "OPTIONS" ||
+		req.Method == "PUT" || req.Method == "DELETE"
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent
+	}
+	switch res.StatusCode {
+	case http.StatusConflict:
+		return lockErrorCodes[newAPIError(req, res.StatusCode, body).Code]
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns exponential backoff with jitter for the given attempt,
+// Retry-After header of the response is honoured.
+func (r RetryConfig) backoff(attempt int, res *http.Response) time.Duration {
+	wait := r.WaitMin
+	for i := 1; i < attempt && wait < r.WaitMax; i++ {
+		wait *= 2
+	}
+	if wait > r.WaitMax {
+		wait = r.WaitMax
+	}
+	if wait > 0 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			retryAfter := time.Duration(seconds) * time.Second
+			if retryAfter > r.WaitMax {
+				retryAfter = r.WaitMax
+			}
+			if retryAfter > wait {
+				wait = retryAfter
+			}
+		}
+	}
+	return wait
+}
+
+// rewindBody prepares body of request to be sent once again
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errors.New("request body can not be replayed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// faultyServer returns status codes from faults one by one with faultBody and 200 afterwards
+func faultyServer(faults []int, faultBody string, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(calls, 1))
+		if call <= len(faults) {
+			w.WriteHeader(faults[call-1])
+			_, _ = w.Write([]byte(faultBody))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+}
+
+func newRetryTestClient(t *testing.T, url string, maxAttempts int) *Client {
+	c, err := NewClient(context.Background(), &url, nil, nil, WithRetry(RetryConfig{
+		MaxAttempts: maxAttempts,
+		WaitMin:     time.Millisecond,
+		WaitMax:     10 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRetry(t *testing.T) {
+	cases := []struct {
+		name          string
+		method        string
+		faults        []int
+		faultBody     string
+		maxAttempts   int
+		expectedCalls int32
+		expectedError bool
+	}{
+		{"get recovers", "GET", []int{503, 502, 504}, "", 4, 4, false},
+		{"get exhausted", "GET", []int{503, 503, 503, 503}, "", 3, 3, true},
+		{"post locked", "POST", []int{409}, `{"code": "LOCK_ERROR", "desc": "object is locked"}`, 4, 2, false},
+		{"post conflict", "POST", []int{409}, `{"code": "CLUSTER_CONFLICT", "desc": "duplicate name"}`, 4, 1, true},
+		{"get conflict without code", "GET", []int{409}, "", 4, 1, true},
+		{"post bad gateway", "POST", []int{502}, "", 4, 1, true},
+		{"no retries", "GET", []int{503}, "", 1, 1, true},
+		{"client error", "GET", []int{400}, "", 4, 1, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			server := faultyServer(tc.faults, tc.faultBody, &calls)
+			defer server.Close()
+			c := newRetryTestClient(t, server.URL, tc.maxAttempts)
+
+			req, err := http.NewRequest(tc.method, server.URL+"/api/v1/cluster/", strings.NewReader(`{"name": "c1"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = c.doRequest(req, nil)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("Unexpected calls count: %d", calls)
+			}
+		})
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 64)
+		n, _ := r.Body.Read(buf)
+		if string(buf[:n]) != `{"name": "c1"}` {
+			t.Errorf("Unexpected body on call %d: %q", calls, buf[:n])
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"code": "LOCK_ERROR"}`))
+		}
+	}))
+	defer server.Close()
+	c := newRetryTestClient(t, server.URL, 2)
+
+	req, err := http.NewRequest("POST", server.URL+"/api/v1/cluster/", strings.NewReader(`{"name": "c1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.doRequest(req, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	r := RetryConfig{MaxAttempts: 10, WaitMin: time.Second, WaitMax: 8 * time.Second}
+	for attempt := 1; attempt < 10; attempt++ {
+		wait := r.backoff(attempt, nil)
+		if wait > r.WaitMax || wait < r.WaitMin/2 {
+			t.Errorf("Unexpected backoff for attempt %d: %s", attempt, wait)
+		}
+	}
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if wait := r.backoff(1, res); wait != 5*time.Second {
+		t.Errorf("Retry-After is not honoured: %s", wait)
+	}
+}
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Statuses of ADCM tasks and jobs
+const (
+	TaskStatusCreated = "created"
+	TaskStatusRunning = "running"
+	TaskStatusLocked  = "locked"
+	TaskStatusSuccess = "success"
+	TaskStatusFailed  = "failed"
+	TaskStatusAborted = "aborted"
+	TaskStatusBroken  = "broken"
+)
+
+// TaskWaitConfig - settings of waiting for ADCM tasks to finish
+type TaskWaitConfig struct {
+	// PollInterval is the delay between task status requests
+	PollInterval time.Duration
+	// LogTailLines limits number of lines of failed job logs included into error
+	LogTailLines int
+	// CancelOnInterrupt enables cancellation of the task if waiting for it is interrupted
+	CancelOnInterrupt bool
+	// LogHandler receives new lines of job logs while task is running, nil disables streaming.
+	// Full logs of running jobs are downloaded on every poll to find new lines.
+	LogHandler JobLogHandler
+	// Events enables subscription to ADCM event websocket to notice task status changes
+	// without delay, polling is used if subscription fails or drops
+	Events bool
+	// EventsPollInterval is the delay between task status requests while subscribed to events
+	EventsPollInterval time.Duration
+}
+
+// DefaultTaskWaitConfig - task wait settings used if not overridden with WithTaskWait
+var DefaultTaskWaitConfig = TaskWaitConfig{
+	PollInterval:       10 * time.Second,
+	LogTailLines:       30,
+	CancelOnInterrupt:  true,
+	EventsPollInterval: time.Minute,
+}
+
+func (w TaskWaitConfig) validate() error {
+	if w.PollInterval <= 0 {
+		return fmt.Errorf("task poll interval must be positive, got %s", w.PollInterval)
+	}
+	if w.Events && w.EventsPollInterval <= 0 {
+		return fmt.Errorf("task events poll interval must be positive, got %s", w.EventsPollInterval)
+	}
+	return nil
+}
+
+// WithTaskWait - set task wait settings of client
+func WithTaskWait(taskWait TaskWaitConfig) Option {
+	return func(c *Client) {
+		c.TaskWait = taskWait
+	}
+}
+
+// taskFinished reports whether status is terminal and will not change anymore
+func taskFinished(status string) bool {
+	switch status {
+	case TaskStatusSuccess, TaskStatusFailed, TaskStatusAborted, TaskStatusBroken:
+		return true
+	}
+	return false
+}
+
+// TaskError - ADCM task finished unsuccessfully
+type TaskError struct {
+	TaskID int64
+	Status string
+	// Job is the first unsuccessful job of the task if any
+	Job *Job
+	// Log holds tail of the logs of Job
+	Log string
+}
+
+func (e *TaskError) Error() string {
+	msg := fmt.Sprintf("task %d finished with status %s", e.TaskID, e.Status)
+	if e.Job != nil {
+		msg += fmt.Sprintf(": job %d %q %s", e.Job.ID, e.Job.DisplayName, e.Job.Status)
+	}
+	if e.Log != "" {
+		msg += "\n" + e.Log
+	}
+	return msg
+}
+
+// TaskInterruptedError - waiting for ADCM task was interrupted
+type TaskInterruptedError struct {
+	TaskID int64
+	// Cancelled reports whether the task was cancelled in ADCM
+	Cancelled bool
+	// Status is the status of the task after interruption if known
+	Status string
+	// Object and ObjectState describe the object action was run on if known
+	Object      string
+	ObjectState string
+	// Cause is the reason of interruption
+	Cause error
+}
+
+func (e *TaskInterruptedError) Error() string {
+	msg := fmt.Sprintf("waiting for task %d interrupted: %s", e.TaskID, e.Cause)
+	if e.Cancelled {
+		msg += "; task cancelled"
+	} else {
+		msg += "; task left running"
+	}
+	if e.Status != "" {
+		msg += fmt.Sprintf(" with status %s", e.Status)
+	}
+	if e.Object != "" {
+		msg += fmt.Sprintf("; %s left in state %q", e.Object, e.ObjectState)
+	}
+	return msg
+}
+
+func (e *TaskInterruptedError) Unwrap() error {
+	return e.Cause
+}
+
+func (c *Client) cancelTask(ctx context.Context, taskID int64) error {
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/api/v1/task/%d/cancel/", c.HostURL, taskID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, nil)
+	return err
+}
+
+// interruptCleanupTimeout limits cleanup after interruption if request timeout is disabled
+const interruptCleanupTimeout = time.Minute
+
+// interruptContext returns context of cleanup requests made after waiting is interrupted.
+// Context of waiting is already done at this point, so a new one limited with request timeout is used.
+func (c *Client) interruptContext() (context.Context, context.CancelFunc) {
+	timeout := c.Timeouts.Request
+	if timeout <= 0 {
+		timeout = interruptCleanupTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// interruptTask cancels the interrupted task if configured to do so and returns its state after cancellation
+func (c *Client) interruptTask(ctx context.Context, interrupted *TaskInterruptedError) *TaskResponse {
+	if !c.TaskWait.CancelOnInterrupt {
+		return nil
+	}
+	err := c.cancelTask(ctx, interrupted.TaskID)
+	if err != nil {
+		interrupted.Cause = fmt.Errorf("%w (could not cancel task: %s)", interrupted.Cause, err)
+		return nil
+	}
+	interrupted.Cancelled = true
+	task, err := c.getTask(ctx, interrupted.TaskID)
+	if err != nil {
+		return nil
+	}
+	interrupted.Status = task.Status
+	return task
+}
+
+// getObjectState returns state of object located at objectPath (e.g. "cluster/1")
+func (c *Client) getObjectState(ctx context.Context, objectPath string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/%s/", c.HostURL, objectPath), nil)
+	if err != nil {
+		return "", err
+	}
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return "", err
+	}
+	var object struct {
+		State string `json:"state"`
+	}
+	err = json.Unmarshal(body, &object)
+	if err != nil {
+		return "", err
+	}
+	return object.State, nil
+}
+
+func (c *Client) getTask(ctx context.Context, taskID int64) (*TaskResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/task/%d/", c.HostURL, taskID), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	var task TaskResponse
+	err = json.Unmarshal(body, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (c *Client) getJobLog(ctx context.Context, jobID, logID int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/job/%d/log/%d/", c.HostURL, jobID, logID), nil)
+	if err != nil {
+		return "", err
+	}
+	body, err := c.doRequest(req, nil)
+	if err != nil {
+		return "", err
+	}
+	var log JobLog
+	err = json.Unmarshal(body, &log)
+	if err != nil {
+		return "", err
+	}
+	// content of logs in json format is not a string
+	var content string
+	if json.Unmarshal(log.Content, &content) != nil {
+		content = string(log.Content)
+	}
+	return content, nil
+}
+
+// getJobLogTail returns last lines of stdout and stderr logs of job
+func (c *Client) getJobLogTail(ctx context.Context, jobID int64) (string, error) {
+	logs, err := getList[JobLog](ctx, c, fmt.Sprintf("%s/api/v1/job/%d/log/", c.HostURL, jobID))
+	if err != nil {
+		return "", err
+	}
+	var tail []string
+	for _, log := range logs {
+		if log.Type != "stdout" && log.Type != "stderr" {
+			continue
+		}
+		content, err := c.getJobLog(ctx, jobID, log.ID)
+		if err != nil {
+			return "", err
+		}
+		lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+		if len(lines) > c.TaskWait.LogTailLines {
+			lines = lines[len(lines)-c.TaskWait.LogTailLines:]
+		}
+		tail = append(tail, fmt.Sprintf("%s %s:\n%s", log.Name, log.Type, strings.Join(lines, "\n")))
+	}
+	return strings.Join(tail, "\n"), nil
+}
+
+// taskError builds error of unsuccessfully finished task with logs of its failed job
+func (c *Client) taskError(ctx context.Context, task *TaskResponse) error {
+	taskErr := &TaskError{TaskID: task.ID, Status: task.Status}
+	for i, job := range task.Jobs {
+		if job.Status != TaskStatusSuccess {
+			taskErr.Job = &task.Jobs[i]
+			break
+		}
+	}
+	if taskErr.Job != nil && taskErr.Job.Status != TaskStatusCreated {
+		log, err := c.getJobLogTail(ctx, taskErr.Job.ID)
+		if err != nil {
+			taskErr.Log = fmt.Sprintf("could not get logs of job %d: %s", taskErr.Job.ID, err)
+		} else {
+			taskErr.Log = log
+		}
+	}
+	return taskErr
+}
+
+// waitTask polls the task until it finishes. Task finished with status other than success
+// is reported with TaskError, interruption of waiting with TaskInterruptedError (the task is
+// cancelled by RunAction then).
+// Job logs are streamed to LogHandler of TaskWait if set, task status is checked on ADCM events if enabled.
+func (c *Client) waitTask(ctx context.Context, taskID int64, action string) (*TaskResponse, error) {
+	taskCtx, cancel := context.WithTimeout(ctx, c.Timeouts.Task)
+	defer cancel()
+	logs := c.newJobLogTailer(action)
+	var events <-chan TaskEvent
+	if c.TaskWait.Events {
+		eventsCtx, stopEvents := context.WithCancel(ctx)
+		defer stopEvents()
+		// subscription is optional, so tasks are polled if it fails
+		events, _ = c.subscribeTaskEvents(eventsCtx)
+	}
+	for {
+		task, err := c.getTask(ctx, taskID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, &TaskInterruptedError{TaskID: taskID, Cause: ctx.Err()}
+			}
+			return nil, err
+		}
+		logs.tail(ctx, task)
+		if taskFinished(task.Status) {
+			if task.Status != TaskStatusSuccess {
+				return task, c.taskError(ctx, task)
+			}
+			return task, nil
+		}
+		events, err = c.waitTaskChange(taskCtx, events, taskID)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return task, fmt.Errorf("task %d did not finish in %s, last status %s", taskID, c.Timeouts.Task, task.Status)
+		}
+		if err != nil {
+			return nil, &TaskInterruptedError{TaskID: taskID, Cause: err}
+		}
+	}
+}
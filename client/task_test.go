@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitTask(t *testing.T) {
+	var calls int32
+	var finalStatus string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/task/1/":
+			call := atomic.AddInt32(&calls, 1)
+			switch {
+			case call == 1:
+				_, _ = w.Write([]byte(`{"id": 1, "status": "created", "jobs": [{"id": 10, "status": "created"}, {"id": 11, "status": "created"}]}`))
+			case call < 4:
+				_, _ = w.Write([]byte(`{"id": 1, "status": "running", "jobs": [{"id": 10, "status": "success"}, {"id": 11, "status": "running"}]}`))
+			default:
+				_, _ = fmt.Fprintf(w, `{"id": 1, "status": %q, "jobs": [{"id": 10, "status": "success"}, {"id": 11, "display_name": "Install", "status": %q}]}`, finalStatus, finalStatus)
+			}
+		case "/api/v1/job/11/log/":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "ansible", "type": "stdout"}, {"id": 2, "name": "ansible", "type": "stderr"}, {"id": 3, "name": "inventory", "type": "check"}]`))
+		case "/api/v1/job/11/log/1/":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "ansible", "type": "stdout", "content": "line 1\nline 2\nline 3\nTASK [install] failed\n"}`))
+		case "/api/v1/job/11/log/2/":
+			_, _ = w.Write([]byte(`{"id": 2, "name": "ansible", "type": "stderr", "content": "permission denied"}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil,
+		WithTaskWait(TaskWaitConfig{PollInterval: time.Millisecond, LogTailLines: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []string{TaskStatusSuccess, TaskStatusFailed, TaskStatusAborted} {
+		t.Run(status, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			finalStatus = status
+			task, err := c.waitTask(context.Background(), 1, "Install")
+			if task == nil || task.Status != status {
+				t.Fatalf("Unexpected task: %v", task)
+			}
+			if status == TaskStatusSuccess {
+				if err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			var taskErr *TaskError
+			if !errors.As(err, &taskErr) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if taskErr.Job == nil || taskErr.Job.ID != 11 {
+				t.Errorf("Unexpected failed job: %v", taskErr.Job)
+			}
+			expectedLog := "ansible stdout:\nline 3\nTASK [install] failed\nansible stderr:\npermission denied"
+			if taskErr.Log != expectedLog {
+				t.Errorf("Unexpected log tail: %q", taskErr.Log)
+			}
+			if !strings.Contains(err.Error(), "Install") {
+				t.Errorf("Failed job is not reported: %s", err)
+			}
+		})
+	}
+
+	// task never finishes
+	c.Timeouts.Task = 20 * time.Millisecond
+	finalStatus = TaskStatusRunning
+	atomic.StoreInt32(&calls, 0)
+	_, err = c.waitTask(context.Background(), 1, "Install")
+	if err == nil || !strings.Contains(err.Error(), "did not finish") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestWaitTaskInterrupted(t *testing.T) {
+	var cancelled int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/cluster/1/action/":
+			_, _ = w.Write([]byte(`[{"id": 7}]`))
+		case "/api/v1/cluster/1/action/7/":
+			_, _ = w.Write([]byte(`{"id": 7, "config": {}}`))
+		case "/api/v1/cluster/1/action/7/run/":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case "/api/v1/cluster/1/":
+			_, _ = w.Write([]byte(`{"id": 1, "state": "created"}`))
+		case "/api/v1/task/1/":
+			if atomic.LoadInt32(&cancelled) > 0 {
+				_, _ = w.Write([]byte(`{"id": 1, "status": "aborted"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 1, "status": "running"}`))
+		case "/api/v1/task/1/cancel/":
+			if r.Method != "PUT" {
+				t.Errorf("Unexpected method of task cancel: %s", r.Method)
+			}
+			atomic.AddInt32(&cancelled, 1)
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	for _, cancelOnInterrupt := range []bool{true, false} {
+		atomic.StoreInt32(&cancelled, 0)
+		c, err := NewClient(context.Background(), &server.URL, nil, nil,
+			WithTaskWait(TaskWaitConfig{PollInterval: time.Millisecond, CancelOnInterrupt: cancelOnInterrupt}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, err = c.RunAction(ctx, ActionObjectCluster, 1, "Install", nil, nil, false)
+		cancel()
+		var interrupted *TaskInterruptedError
+		if !errors.As(err, &interrupted) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if interrupted.Cancelled != cancelOnInterrupt || (atomic.LoadInt32(&cancelled) > 0) != cancelOnInterrupt {
+			t.Errorf("Unexpected cancellation of task with cancel on interrupt %v: %v", cancelOnInterrupt, err)
+		}
+		if interrupted.Object != "cluster/1" || interrupted.ObjectState != "created" {
+			t.Errorf("Unexpected object state: %v", err)
+		}
+		if cancelOnInterrupt && interrupted.Status != TaskStatusAborted {
+			t.Errorf("Unexpected task status: %v", err)
+		}
+	}
+}
+
+func TestTaskWaitValidation(t *testing.T) {
+	for _, taskWait := range []TaskWaitConfig{
+		{PollInterval: 0},
+		{PollInterval: -time.Second},
+		{PollInterval: time.Second, Events: true, EventsPollInterval: 0},
+	} {
+		_, err := NewClient(context.Background(), nil, nil, nil, WithTaskWait(taskWait))
+		if err == nil {
+			t.Errorf("Invalid task wait settings are accepted: %+v", taskWait)
+		}
+	}
+}
+
+func TestInterruptCleanupTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/cluster/1/action/":
+			_, _ = w.Write([]byte(`[{"id": 3, "name": "Install"}]`))
+		case "/api/v1/cluster/1/action/3/":
+			_, _ = w.Write([]byte(`{"id": 3, "name": "Install", "config": null}`))
+		case "/api/v1/cluster/1/action/3/run/":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case "/api/v1/task/1/":
+			_, _ = w.Write([]byte(`{"id": 1, "status": "running"}`))
+		default:
+			// cancel and object state requests hang
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil,
+		WithRetry(RetryConfig{MaxAttempts: 4, WaitMin: 10 * time.Millisecond, WaitMax: 10 * time.Millisecond}),
+		WithTimeouts(Timeouts{Request: 100 * time.Millisecond, Upload: time.Minute, Task: time.Minute}),
+		WithTaskWait(TaskWaitConfig{PollInterval: time.Millisecond, CancelOnInterrupt: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = c.RunAction(ctx, ActionObjectCluster, 1, "Install", nil, nil, false)
+	var interrupted *TaskInterruptedError
+	if !errors.As(err, &interrupted) || interrupted.Cancelled {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Cleanup after interruption is not limited: %s", elapsed)
+	}
+}
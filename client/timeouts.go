@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Timeouts - timeouts of client operations
+type Timeouts struct {
+	// Request limits every API request including reading its response, zero disables the limit
+	Request time.Duration
+	// Upload limits download of bundle and its upload to ADCM
+	Upload time.Duration
+	// Task limits waiting for ADCM task to finish
+	Task time.Duration
+}
+
+// DefaultTimeouts - timeouts used if not overridden with WithTimeouts
+var DefaultTimeouts = Timeouts{
+	Request: 10 * time.Second,
+	Upload:  30 * time.Minute,
+	Task:    time.Hour,
+}
+
+// WithTimeouts - set timeouts of client
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(c *Client) {
+		c.Timeouts = timeouts
+	}
+}
+
+func (t Timeouts) validate() error {
+	switch {
+	case t.Request < 0:
+		return fmt.Errorf("request timeout must not be negative, got %s", t.Request)
+	case t.Upload <= 0:
+		return fmt.Errorf("upload timeout must be positive, got %s", t.Upload)
+	case t.Task <= 0:
+		return fmt.Errorf("task timeout must be positive, got %s", t.Task)
+	}
+	return nil
+}
+
+type requestTimeoutKey struct{}
+
+// withRequestTimeout overrides request timeout for requests made with returned context,
+// zero timeout means requests are limited only by the context itself.
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+func (c *Client) requestTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.Timeouts.Request
+}
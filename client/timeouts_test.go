@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil,
+		WithRetry(RetryConfig{MaxAttempts: 1}),
+		WithTimeouts(Timeouts{Request: 50 * time.Millisecond, Upload: time.Second, Task: time.Second}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("GET", server.URL+"/api/v1/stack/bundle/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.doRequest(req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Request timeout is not applied: %v", err)
+	}
+
+	// long-running transfers are limited only by their own context
+	req, err = http.NewRequestWithContext(withRequestTimeout(context.Background(), 0), "GET", server.URL+"/api/v1/stack/bundle/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.doRequest(req, nil)
+	if err != nil {
+		t.Errorf("Request timeout is applied to long-running request: %v", err)
+	}
+}
+
+func TestTimeoutsValidation(t *testing.T) {
+	for _, timeouts := range []Timeouts{
+		{Request: -time.Second, Upload: time.Minute, Task: time.Minute},
+		{Request: time.Second, Upload: 0, Task: time.Minute},
+		{Request: time.Second, Upload: time.Minute, Task: 0},
+	} {
+		_, err := NewClient(context.Background(), nil, nil, nil, WithTimeouts(timeouts))
+		if err == nil {
+			t.Errorf("Invalid timeouts are accepted: %+v", timeouts)
+		}
+	}
+	_, err := NewClient(context.Background(), nil, nil, nil, WithTimeouts(Timeouts{Upload: time.Minute, Task: time.Minute}))
+	if err != nil {
+		t.Errorf("Request timeout is not disabled with zero: %v", err)
+	}
+}
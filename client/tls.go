@@ -0,0 +1,88 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSSettings - TLS settings of connection to ADCM
+type TLSSettings struct {
+	// CACertFile is a path to PEM encoded CA bundle
+	CACertFile string
+	// CACertPEM is PEM encoded CA bundle
+	CACertPEM string
+	// ClientCert and ClientKey are PEM encoded client certificate and key or paths to them
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables verification of server certificate
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig - build TLS config from settings
+func NewTLSConfig(settings TLSSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+
+	if settings.CACertFile != "" || settings.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if settings.CACertFile != "" {
+			data, err := os.ReadFile(settings.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("no certificates found in CA certificate file %s", settings.CACertFile)
+			}
+		}
+		if settings.CACertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(settings.CACertPEM)) {
+				return nil, fmt.Errorf("no certificates found in CA certificate PEM")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.ClientCert != "" || settings.ClientKey != "" {
+		if settings.ClientCert == "" || settings.ClientKey == "" {
+			return nil, fmt.Errorf("both client certificate and client key must be set")
+		}
+		certPEM, err := readPEM(settings.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(settings.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns PEM content as is or reads it from file
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// WithTLSConfig - use TLS config for connections to ADCM
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Client) {
+		c.TLSConfig = tlsConfig
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTLSTestClient(t *testing.T, url string, settings TLSSettings) *Client {
+	tlsConfig, err := NewTLSConfig(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(context.Background(), &url, nil, nil, WithTLSConfig(tlsConfig), WithRetry(RetryConfig{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func generateClientCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caPEM), 0600); err != nil {
+		t.Fatal(err)
+	}
+	clientCert, clientKey := generateClientCert(t)
+	clientKeyFile := filepath.Join(t.TempDir(), "client.key")
+	if err := os.WriteFile(clientKeyFile, []byte(clientKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		settings TLSSettings
+		success  bool
+	}{
+		{"untrusted", TLSSettings{ClientCert: clientCert, ClientKey: clientKey}, false},
+		{"ca pem", TLSSettings{CACertPEM: caPEM, ClientCert: clientCert, ClientKey: clientKey}, true},
+		{"ca file and key file", TLSSettings{CACertFile: caFile, ClientCert: clientCert, ClientKey: clientKeyFile}, true},
+		{"insecure", TLSSettings{InsecureSkipVerify: true, ClientCert: clientCert, ClientKey: clientKey}, true},
+		{"no client certificate", TLSSettings{CACertPEM: caPEM}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTLSTestClient(t, server.URL, tc.settings)
+			_, err := c.GetBundles(context.Background())
+			if (err == nil) != tc.success {
+				t.Errorf("Unexpected result: %v", err)
+			}
+		})
+	}
+
+	if _, err := NewTLSConfig(TLSSettings{ClientCert: clientCert}); err == nil {
+		t.Error("Client certificate without key accepted")
+	}
+	if _, err := NewTLSConfig(TLSSettings{CACertPEM: "not a certificate"}); err == nil {
+		t.Error("Invalid CA certificate accepted")
+	}
+}
@@ -1,17 +1,84 @@
 package client
 
-import "encoding/json"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
 
 type results struct {
-	Results interface{} `json:"results"`
+	Count    int         `json:"count"`
+	Next     *string     `json:"next"`
+	Previous *string     `json:"previous"`
+	Results  interface{} `json:"results"`
 }
 
+// unwrapResults decodes list items of a single page into placeholder.
+// Both paginated {"count": ..., "next": ..., "results": [...]} and bare array responses are supported.
 func unwrapResults(body []byte, placeholder interface{}) error {
+	_, err := unwrapPage(body, placeholder)
+	return err
+}
+
+func unwrapPage(body []byte, placeholder interface{}) (*results, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		return nil, json.Unmarshal(trimmed, placeholder)
+	}
 	var obj results
 	obj.Results = placeholder
 	err := json.Unmarshal(body, &obj)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// getList fetches every item of list endpoint following pagination.
+// Next page is requested by "next" link if provided, otherwise by limit and offset.
+func getList[T any](ctx context.Context, c *Client, listURL string) ([]T, error) {
+	baseURL, err := url.Parse(listURL)
+	if err != nil {
+		return nil, err
+	}
+	var items []T
+	pageURL := listURL
+	for {
+		req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.doRequest(req, nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageItems []T
+		page, err := unwrapPage(body, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if page == nil || len(pageItems) == 0 || len(items) >= page.Count {
+			return items, nil
+		}
+		if page.Next != nil && *page.Next != "" {
+			nextURL, err := baseURL.Parse(*page.Next)
+			if err != nil {
+				return nil, err
+			}
+			// ADCM behind proxy may advertise its internal address in links
+			nextURL.Scheme = baseURL.Scheme
+			nextURL.Host = baseURL.Host
+			pageURL = nextURL.String()
+			continue
+		}
+		query := baseURL.Query()
+		query.Set("offset", strconv.Itoa(len(items)))
+		query.Set("limit", strconv.Itoa(len(pageItems)))
+		nextURL := *baseURL
+		nextURL.RawQuery = query.Encode()
+		pageURL = nextURL.String()
 	}
-	return nil
 }
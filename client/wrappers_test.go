@@ -1,6 +1,11 @@
 package client
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestUnwrap(t *testing.T) {
 	receivedJson := `
@@ -47,3 +52,58 @@ func TestUnwrap(t *testing.T) {
 		t.Error("Unexpected results count")
 	}
 }
+
+func TestUnwrapBareArray(t *testing.T) {
+	var ids []Identifier
+	err := unwrapResults([]byte(` [{"id": 1}, {"id": 2}]`), &ids)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(ids) != 2 {
+		t.Error("Unexpected results count")
+	}
+}
+
+func TestGetList(t *testing.T) {
+	pages := map[string]string{
+		// pages linked with next, host in links differs from the one of client
+		"/api/v1/stack/bundle/":        `{"count": 5, "next": "http://adcm.internal:8000/api/v1/stack/bundle/?page=2", "results": [{"id": 1}, {"id": 2}]}`,
+		"/api/v1/stack/bundle/?page=2": `{"count": 5, "next": "http://adcm.internal:8000/api/v1/stack/bundle/?page=3", "results": [{"id": 3}, {"id": 4}]}`,
+		"/api/v1/stack/bundle/?page=3": `{"count": 5, "next": null, "results": [{"id": 5}]}`,
+		// pages without next links
+		"/api/v1/cluster/":                  `{"count": 3, "results": [{"id": 1}, {"id": 2}]}`,
+		"/api/v1/cluster/?limit=2&offset=2": `{"count": 3, "results": [{"id": 3}]}`,
+		// not paginated
+		"/api/v1/host/": `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			t.Errorf("Unexpected request: %s", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(context.Background(), &server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for endpoint, expected := range map[string]int{"stack/bundle/": 5, "cluster/": 3, "host/": 3} {
+		ids, err := getList[Identifier](context.Background(), c, server.URL+"/api/v1/"+endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != expected {
+			t.Errorf("Unexpected results count for %s: %d", endpoint, len(ids))
+		}
+		for i, id := range ids {
+			if id.ID != int64(i+1) {
+				t.Errorf("Unexpected result order for %s: %v", endpoint, ids)
+				break
+			}
+		}
+	}
+}